@@ -26,6 +26,7 @@ import (
 
 	"github.com/urfave/cli"
 
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
 	"github.com/gambol99/resources/pkg/controllers"
 	"github.com/gambol99/resources/pkg/controllers/api"
 	"github.com/gambol99/resources/pkg/version"
@@ -56,6 +57,38 @@ func main() {
 			EnvVar: "PROVIDER_NAME",
 			Value:  "resource.appvia.io/default",
 		},
+		cli.StringFlag{
+			Name:   "policy-dir",
+			Usage:  "an optional directory of rego policies to evaluate the resources against `PATH`",
+			EnvVar: "POLICY_DIR",
+		},
+		cli.StringFlag{
+			Name:   "policy-namespace",
+			Usage:  "the namespace to watch for configmaps containing rego policies `NAMESPACE`",
+			EnvVar: "POLICY_NAMESPACE",
+			Value:  "kube-system",
+		},
+		cli.BoolFlag{
+			Name:   "enable-admission",
+			Usage:  "indicates you wish to enable the admission webhook server `BOOL`",
+			EnvVar: "ENABLE_ADMISSION",
+		},
+		cli.StringFlag{
+			Name:   "admission-listen",
+			Usage:  "the interface the admission webhooks should listen on `INTERFACE`",
+			EnvVar: "ADMISSION_LISTEN",
+			Value:  ":8443",
+		},
+		cli.StringFlag{
+			Name:   "tls-cert",
+			Usage:  "the path to the certificate used by the admission webhooks `PATH`",
+			EnvVar: "TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   "tls-key",
+			Usage:  "the path to the private key used by the admission webhooks `PATH`",
+			EnvVar: "TLS_KEY",
+		},
 		cli.BoolTFlag{
 			Name:   "enable-metrics",
 			Usage:  "indicated you wish to enable the metrics endpoint `BOOL`",
@@ -73,6 +106,40 @@ func main() {
 			EnvVar: "STACK_TIMEOUT",
 			Value:  time.Minute * 30,
 		},
+		cli.StringFlag{
+			Name:   "stack-timeout-action",
+			Usage:  "the default action on a stack operation exceeding the timeout, i.e. Cancel, Leave or Fail `ACTION`",
+			EnvVar: "STACK_TIMEOUT_ACTION",
+			Value:  apiv1.TimeoutActionCancel,
+		},
+		cli.StringFlag{
+			Name:   "supersede-policy",
+			Usage:  "the default policy on a resource changing while its stack is being updated, i.e. Cancel or Coalesce `POLICY`",
+			EnvVar: "SUPERSEDE_POLICY",
+			Value:  apiv1.SupersedeCoalesce,
+		},
+		cli.DurationFlag{
+			Name:   "lookup-ttl",
+			Usage:  "the duration template lookups are cached across renders, zero disables `DURATION`",
+			EnvVar: "LOOKUP_TTL",
+			Value:  0,
+		},
+		cli.IntFlag{
+			Name:   "max-retries",
+			Usage:  "the max attempts to retry a failed reconcile which may succeed on retry `NUMBER`",
+			EnvVar: "MAX_RETRIES",
+			Value:  10,
+		},
+		cli.StringFlag{
+			Name:   "template-bundle-dir",
+			Usage:  "an optional directory of templates synchronized into the cloud templates `PATH`",
+			EnvVar: "TEMPLATE_BUNDLE_DIR",
+		},
+		cli.StringFlag{
+			Name:   "templates-dir",
+			Usage:  "an optional directory of template content referenced by the cloud templates `PATH`",
+			EnvVar: "TEMPLATES_DIR",
+		},
 		cli.StringFlag{
 			Name:   "kubeconfig",
 			Usage:  "An optional path to a kubernetes client configuration `PATH`",
@@ -102,20 +169,41 @@ func main() {
 			EnvVar: "VERBOSE",
 		},
 	}
+	app.Commands = []cli.Command{
+		templateCommand(),
+	}
 	app.Action = func(cx *cli.Context) error {
 		return func() error {
+			if action := cx.String("stack-timeout-action"); !apiv1.IsValidTimeoutAction(action) {
+				return fmt.Errorf("unsupported stack timeout action: %s, expected one of %v", action, apiv1.TimeoutActions)
+			}
+			if policy := cx.String("supersede-policy"); !apiv1.IsValidSupersedePolicy(policy) {
+				return fmt.Errorf("unsupported supersede policy: %s, expected one of %v", policy, apiv1.SupersedePolicies)
+			}
 			c, err := controllers.New(&api.Config{
-				CloudProvider:     cx.String("cloud"),
-				ClusterName:       cx.String("cluster"),
-				ElectionNamespace: cx.String("election-namespace"),
-				EnableMetrics:     cx.Bool("enable-metrics"),
-				KubeConfig:        os.ExpandEnv(cx.String("kubeconfig")),
-				MetricsListen:     cx.String("metrics-listen"),
-				Name:              cx.String("name"),
-				ResyncDuration:    cx.Duration("resync-duration"),
-				StackTimeout:      cx.Duration("stack-timeout"),
-				Threadness:        cx.Int("threadness"),
-				Verbose:           cx.Bool("verbose"),
+				AdmissionListen:    cx.String("admission-listen"),
+				CloudProvider:      cx.String("cloud"),
+				ClusterName:        cx.String("cluster"),
+				ElectionNamespace:  cx.String("election-namespace"),
+				EnableAdmission:    cx.Bool("enable-admission"),
+				EnableMetrics:      cx.Bool("enable-metrics"),
+				LookupTTL:          cx.Duration("lookup-ttl"),
+				KubeConfig:         os.ExpandEnv(cx.String("kubeconfig")),
+				MaxRetries:         cx.Int("max-retries"),
+				MetricsListen:      cx.String("metrics-listen"),
+				Name:               cx.String("name"),
+				PolicyDir:          os.ExpandEnv(cx.String("policy-dir")),
+				PolicyNamespace:    cx.String("policy-namespace"),
+				ResyncDuration:     cx.Duration("resync-duration"),
+				StackTimeout:       cx.Duration("stack-timeout"),
+				StackTimeoutAction: cx.String("stack-timeout-action"),
+				SupersedePolicy:    cx.String("supersede-policy"),
+				TemplateBundleDir:  cx.String("template-bundle-dir"),
+				TemplatesDir:       cx.String("templates-dir"),
+				Threadness:         cx.Int("threadness"),
+				TLSCert:            os.ExpandEnv(cx.String("tls-cert")),
+				TLSKey:             os.ExpandEnv(cx.String("tls-key")),
+				Verbose:            cx.Bool("verbose"),
 			})
 			if err != nil {
 				return err
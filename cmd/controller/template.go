@@ -0,0 +1,138 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/gambol99/resources/pkg/cloud/aws"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// templateCommand returns the commands for working with templates
+func templateCommand() cli.Command {
+	return cli.Command{
+		Name:  "template",
+		Usage: "provides commands for working with cloud templates",
+		Subcommands: []cli.Command{
+			{
+				Name:  "test",
+				Usage: "runs the test cases of the cloud templates without access to the cluster or cloud",
+				Flags: []cli.Flag{
+					cli.StringSliceFlag{
+						Name:  "file,f",
+						Usage: "a yaml file containing templates, fragments or template tests `PATH`",
+					},
+					cli.StringFlag{
+						Name:  "golden-dir",
+						Usage: "an optional directory of golden files named <template>/<test>.golden `PATH`",
+					},
+					cli.BoolFlag{
+						Name:  "update",
+						Usage: "write the rendered templates to the golden directory rather than comparing",
+					},
+					cli.StringFlag{
+						Name:  "templates-dir",
+						Usage: "an optional directory of template content referenced by the templates `PATH`",
+					},
+					cli.StringFlag{
+						Name:  "cluster",
+						Usage: "the name of the cluster presented to the templates `NAME`",
+						Value: "test",
+					},
+					cli.StringFlag{
+						Name:  "region",
+						Usage: "the region presented to the templates, overridden by the test stubs `NAME`",
+					},
+				},
+				Action: runTemplateTests,
+			},
+		},
+	}
+}
+
+// runTemplateTests is responsible for running the test cases of the templates
+func runTemplateTests(cx *cli.Context) error {
+	if len(cx.StringSlice("file")) <= 0 {
+		return fmt.Errorf("no template files specified")
+	}
+	manifests, err := utils.ReadManifests(cx.StringSlice("file")...)
+	if err != nil {
+		return err
+	}
+	config := &models.ProviderConfig{ClusterName: cx.String("cluster"), Region: cx.String("region")}
+	loader := utils.NewContentLoader(nil, cx.String("templates-dir"))
+	goldens := cx.String("golden-dir")
+
+	var total, failed int
+	for _, template := range manifests.Templates {
+		if len(template.Spec.Tests) <= 0 {
+			continue
+		}
+		resolved, err := manifests.Resolve(loader, template)
+		if err != nil {
+			return fmt.Errorf("template: %s, error: %s", template.Name, err)
+		}
+
+		for _, x := range aws.RunTemplateTests(context.Background(), config, resolved.Template, resolved.Partials) {
+			total++
+
+			// @step: compare or update the golden file of the test case
+			if goldens != "" && x.Rendered != "" {
+				path := filepath.Join(goldens, template.Name, x.Name+".golden")
+				if cx.Bool("update") {
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						return err
+					}
+					if err := ioutil.WriteFile(path, []byte(x.Rendered), 0644); err != nil {
+						return err
+					}
+				} else if expected, err := ioutil.ReadFile(path); err == nil {
+					if diff := models.DiffLines(string(expected), x.Rendered); diff != "" {
+						x.Failures = append(x.Failures, fmt.Sprintf("rendered template differs from golden: %s\n%s", path, diff))
+					}
+				} else if !os.IsNotExist(err) {
+					return err
+				}
+			}
+
+			if x.Passed() {
+				fmt.Fprintf(os.Stdout, "PASS %s/%s\n", template.Name, x.Name)
+				continue
+			}
+			failed++
+			fmt.Fprintf(os.Stdout, "FAIL %s/%s\n", template.Name, x.Name)
+			for _, reason := range x.Failures {
+				fmt.Fprintf(os.Stdout, "    %s\n", reason)
+			}
+		}
+	}
+	fmt.Fprintf(os.Stdout, "%d tests, %d failed\n", total, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d template tests failed", failed, total)
+	}
+
+	return nil
+}
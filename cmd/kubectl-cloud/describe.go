@@ -0,0 +1,225 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// redacted is shown in place of output values
+	redacted = "<redacted>"
+)
+
+// describeCommand returns the command to describe a cloud resource
+func describeCommand() cli.Command {
+	return cli.Command{
+		Name:      "describe",
+		Usage:     "describes the cloud resource, template, status, stack events and outputs",
+		ArgsUsage: "NAME",
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "events",
+				Usage: "the maximum number of stack events to show `COUNT`",
+				Value: 10,
+			},
+		},
+		Action: func(cx *cli.Context) error {
+			name, err := getResourceName(cx)
+			if err != nil {
+				return err
+			}
+			c, err := makeClients(cx)
+			if err != nil {
+				return err
+			}
+
+			return c.describe(context.Background(), os.Stdout, name, cx.Int("events"))
+		},
+	}
+}
+
+// describe writes the description of the cloud resource
+func (c *clients) describe(ctx context.Context, out io.Writer, name string, maxEvents int) error {
+	resource, err := c.resources.CloudV1().CloudResources(c.namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	stackname := models.GetStackName(resource.Name, resource.Namespace)
+
+	fmt.Fprintf(w, "Name:\t%s\n", resource.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", resource.Namespace)
+	fmt.Fprintf(w, "Created:\t%s\n", resource.CreationTimestamp.Format(time.RFC3339))
+	if request, found := resource.Annotations[models.ReconcileAnnotation]; found {
+		fmt.Fprintf(w, "Reconcile Requested:\t%s\n", request)
+	}
+	fmt.Fprintf(w, "Stack:\t%s\n", stackname)
+
+	// @step: describe the template the resource is built from
+	template, err := c.resources.CloudV1().CloudTemplates().Get(resource.Spec.TemplateName, metav1.GetOptions{})
+	switch {
+	case err != nil:
+		fmt.Fprintf(w, "Template:\t%s (%s)\n", resource.Spec.TemplateName, err)
+	default:
+		fmt.Fprintf(w, "Template:\t%s (%s)\n", template.Name, getValue(template.Status.Status, "Unknown"))
+		if warning := template.GetDeprecationWarning(); warning != "" {
+			fmt.Fprintf(w, "  Warning:\t%s\n", warning)
+		}
+	}
+
+	fmt.Fprintf(w, "Parameters:\n")
+	for _, x := range resource.Spec.Parameters {
+		switch {
+		case x.Value != nil:
+			fmt.Fprintf(w, "  %s:\t%s\n", x.Name, *x.Value)
+		case x.SecretName != nil:
+			fmt.Fprintf(w, "  %s:\t<secret: %s>\n", x.Name, *x.SecretName)
+		}
+	}
+
+	// @step: describe the status recorded by the controller
+	status, err := c.resources.CloudV1().CloudStatuses(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		fmt.Fprintf(w, "Status:\t<none>\n")
+	case err != nil:
+		fmt.Fprintf(w, "Status:\t%s\n", err)
+	default:
+		fmt.Fprintf(w, "Status:\t%s\n", getValue(status.Status, "Unknown"))
+		if status.Message != "" {
+			fmt.Fprintf(w, "  Message:\t%s\n", status.Message)
+		}
+		if status.Reason != "" {
+			fmt.Fprintf(w, "  Reason:\t%s\n", status.Reason)
+		}
+		if x := status.Operation; x != nil {
+			fmt.Fprintf(w, "  Operation:\t%s (%s, started %s)\n", x.Type, x.ID, x.StartTime.Format(time.RFC3339))
+			if x.TimeoutAction != "" {
+				fmt.Fprintf(w, "  Timed Out:\t%s after %s\n", x.TimeoutAction, x.Timeout.Duration)
+			}
+			if s := x.Superseded; s != nil {
+				fmt.Fprintf(w, "  Superseded:\t%s (%d changes, latest %s)\n", s.Policy, s.Count, s.LastTransitionTime.Format(time.RFC3339))
+			}
+		}
+		if x := status.Checkpoint; x != nil {
+			fmt.Fprintf(w, "  Checkpoint:\t%s (%s)\n", x.Step, x.LastTransitionTime.Format(time.RFC3339))
+		}
+		if len(status.Conditions) > 0 {
+			fmt.Fprintf(w, "Conditions:\n  Type\tStatus\tReason\tMessage\n")
+			for _, x := range status.Conditions {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", x.Type, x.Status, x.Reason, x.Message)
+			}
+		}
+	}
+
+	// @step: describe the changes the template would make to the stack when last checked
+	if status != nil && status.Diff != nil {
+		fmt.Fprintf(w, "Pending Changes:\t%t (checked %s)\n", status.Diff.HasChanges(), status.Diff.LastCheckedTime.Format(time.RFC3339))
+	}
+
+	// @step: describe the secrets generated for the resource
+	if len(resource.Spec.Secrets) > 0 {
+		fmt.Fprintf(w, "Secrets:\n")
+		for _, x := range resource.Spec.Secrets {
+			fmt.Fprintf(w, "  %s:\t%s\n", x.Name, c.describeSecret(resource.Namespace, x))
+		}
+	}
+
+	// @step: describe the outputs and events of the stack, the values of outputs are redacted
+	if c.cloud == nil {
+		fmt.Fprintf(w, "Outputs:\t<unavailable: %s>\n", c.cloudErr)
+		return nil
+	}
+	stack, err := c.cloud.Get(ctx, stackname, &models.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(w, "Outputs:\t<unavailable: %s>\n", err)
+		return nil
+	}
+	fmt.Fprintf(w, "Stack Status:\t%s (%s)\n", getValue(stack.Status.Phase, "Unknown"), getValue(stack.Status.ProviderStatus, "-"))
+	if stack.Status.Reason != "" {
+		fmt.Fprintf(w, "Stack Reason:\t%s\n", stack.Status.Reason)
+	}
+	fmt.Fprintf(w, "Outputs:\n")
+	var keys []string
+	for k := range stack.Spec.Outputs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %s:\t%s\n", k, redacted)
+	}
+
+	events, err := c.cloud.Events(ctx, stackname, &models.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(w, "Events:\t<unavailable: %s>\n", err)
+		return nil
+	}
+	if len(events) > maxEvents {
+		events = events[:maxEvents]
+	}
+	fmt.Fprintf(w, "Events:\n  Time\tStatus\tType\tResource\tReason\n")
+	for i := len(events) - 1; i >= 0; i-- {
+		x := events[i]
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", x.Time.Format(time.RFC3339), x.Status, x.Type, x.LogicalID, x.Reason)
+	}
+
+	return nil
+}
+
+// describeSecret returns the keys of the generated secret, never the values
+func (c *clients) describeSecret(namespace string, mapping apiv1.Secret) string {
+	secret, err := c.client.CoreV1().Secrets(namespace).Get(mapping.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return "<not created>"
+		}
+		return err.Error()
+	}
+	var keys []string
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return fmt.Sprintf("keys: %s", strings.Join(keys, ","))
+}
+
+// getValue returns the value or the default when empty
+func getValue(value, def string) string {
+	if value == "" {
+		return def
+	}
+
+	return value
+}
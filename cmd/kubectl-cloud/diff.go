@@ -0,0 +1,134 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// diffCommand returns the command to compare the deployed stack with the template as it renders now
+func diffCommand() cli.Command {
+	return cli.Command{
+		Name:      "diff",
+		Usage:     "renders the cloud resource against the current template and compares it with the deployed stack",
+		ArgsUsage: "NAME",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "templates-dir",
+				Usage: "the directory of template content referenced by the templates, as mounted in the controller `PATH`",
+			},
+		},
+		Action: func(cx *cli.Context) error {
+			name, err := getResourceName(cx)
+			if err != nil {
+				return err
+			}
+			if cx.GlobalString("cluster") == "" {
+				return fmt.Errorf("you must specify the cluster name the controller renders with")
+			}
+			c, err := makeClients(cx)
+			if err != nil {
+				return err
+			}
+			if c.cloud == nil {
+				return fmt.Errorf("unable to create the cloud provider: %s", c.cloudErr)
+			}
+			diff, err := c.diff(context.Background(), name, utils.NewContentLoader(c.client, cx.String("templates-dir")))
+			if err != nil {
+				return err
+			}
+			writeDiff(os.Stdout, diff)
+
+			return nil
+		},
+	}
+}
+
+// diff renders the resource as the controller would and compares it with the deployed stack
+func (c *clients) diff(ctx context.Context, name string, loader *utils.ContentLoader) (*apiv1.StackDiff, error) {
+	resource, err := c.resources.CloudV1().CloudResources(c.namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	template, err := utils.FindCloudTemplate(c.resources, resource.Spec.TemplateName)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := utils.ResolveCloudTemplate(c.resources, loader, template)
+	if err != nil {
+		return nil, err
+	}
+	model, err := models.MakeResourceModel(resolved.Template, resource, func(name, namespace string) (map[string]string, error) {
+		return utils.FindKubernetesSecret(c.client, name, namespace)
+	})
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := c.client.CoreV1().Namespaces().Get(resource.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cloud.Diff(ctx, models.GetStackName(resource.Name, resource.Namespace), &models.CreateOptions{
+		Context:         model,
+		NamespaceLabels: namespace.Labels,
+		Partials:        resolved.Partials,
+		Resource:        resource,
+		Tags:            models.MakeStackTags(resource, c.provider),
+		Template:        resolved.Template,
+	})
+}
+
+// writeDiff writes the changes to the stack
+func writeDiff(out io.Writer, diff *apiv1.StackDiff) {
+	if !diff.HasChanges() {
+		fmt.Fprintln(out, "no changes, the stack matches the template")
+		return
+	}
+	if diff.Template != "" {
+		fmt.Fprintf(out, "Template:\n%s\n", indent(diff.Template))
+	}
+	if len(diff.Tags) > 0 {
+		fmt.Fprintf(out, "Tags:\n")
+		for _, x := range diff.Tags {
+			switch {
+			case x.Deployed == "":
+				fmt.Fprintf(out, "  + %s: %s\n", x.Key, x.Rendered)
+			case x.Rendered == "":
+				fmt.Fprintf(out, "  - %s: %s\n", x.Key, x.Deployed)
+			default:
+				fmt.Fprintf(out, "  ~ %s: %s -> %s\n", x.Key, x.Deployed, x.Rendered)
+			}
+		}
+	}
+}
+
+// indent prefixes each of the lines of the content
+func indent(content string) string {
+	return "  " + strings.Replace(content, "\n", "\n  ", -1)
+}
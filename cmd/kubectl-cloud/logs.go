@@ -0,0 +1,104 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// logsCommand returns the command to print the stack events of a cloud resource
+func logsCommand() cli.Command {
+	return cli.Command{
+		Name:      "logs",
+		Usage:     "prints the stack events of the cloud resource, optionally streaming new events",
+		ArgsUsage: "NAME",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "follow,f",
+				Usage: "indicates the events should be streamed until interrupted",
+			},
+			cli.DurationFlag{
+				Name:  "interval",
+				Usage: "the interval between checking for new events when following `DURATION`",
+				Value: 5 * time.Second,
+			},
+		},
+		Action: func(cx *cli.Context) error {
+			name, err := getResourceName(cx)
+			if err != nil {
+				return err
+			}
+			c, err := makeClients(cx)
+			if err != nil {
+				return err
+			}
+			if c.cloud == nil {
+				return fmt.Errorf("unable to create the cloud provider: %s", c.cloudErr)
+			}
+			resource, err := c.resources.CloudV1().CloudResources(c.namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			ctx, cancel := signalContext()
+			defer cancel()
+
+			return c.logs(ctx, os.Stdout, models.GetStackName(resource.Name, resource.Namespace), cx.Bool("follow"), cx.Duration("interval"))
+		},
+	}
+}
+
+// logs writes the events of the stack oldest first, when following only the new events are written
+func (c *clients) logs(ctx context.Context, out io.Writer, stackname string, follow bool, interval time.Duration) error {
+	seen := make(map[string]bool, 0)
+
+	for {
+		events, err := c.cloud.Events(ctx, stackname, &models.GetOptions{})
+		if err != nil {
+			// @check an interrupt while following is not an error
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for i := len(events) - 1; i >= 0; i-- {
+			x := events[i]
+			if seen[x.ID] {
+				continue
+			}
+			seen[x.ID] = true
+			fmt.Fprintf(out, "%s %s %s %s %s\n", x.Time.Format(time.RFC3339), x.Status, x.Type, x.LogicalID, x.Reason)
+		}
+		if !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
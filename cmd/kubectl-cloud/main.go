@@ -0,0 +1,170 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/gambol99/resources/pkg/client/clientset/versioned"
+	"github.com/gambol99/resources/pkg/cloud/aws"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/version"
+)
+
+// pluginName is the name of the plugin
+const pluginName = "kubectl-cloud"
+
+// clients are the api clients used by the commands
+type clients struct {
+	// client is the kubernetes client
+	client kubernetes.Interface
+	// cloud is the cloud provider, nil when the provider could not be created
+	cloud models.CloudProvider
+	// cloudErr is the reason the cloud provider is not available
+	cloudErr error
+	// namespace is the namespace of the resources
+	namespace string
+	// provider is the name of the controller which owns the stacks
+	provider string
+	// resources is the cloud resources client
+	resources versioned.Interface
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = pluginName
+	app.Usage = "a kubectl plugin for inspecting and debugging cloud resources"
+	app.Version = version.GetVersion()
+	app.Author = version.Author
+	app.Email = version.Email
+	app.Compiled = version.GetBuildTime()
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   "kubeconfig",
+			Usage:  "the path to the kubeconfig, defaults to the kubectl loading rules `PATH`",
+			EnvVar: "KUBECONFIG",
+		},
+		cli.StringFlag{
+			Name:  "context",
+			Usage: "the name of the kubeconfig context to use `NAME`",
+		},
+		cli.StringFlag{
+			Name:  "namespace,n",
+			Usage: "the namespace of the cloud resource, defaults to the namespace of the context `NAMESPACE`",
+		},
+		cli.StringFlag{
+			Name:   "cluster",
+			Usage:  "the name of the cluster presented to the templates when rendering `NAME`",
+			EnvVar: "CLUSTER_NAME",
+		},
+		cli.StringFlag{
+			Name:   "provider-name",
+			Usage:  "the name of the controller which owns the stacks `NAME`",
+			EnvVar: "PROVIDER_NAME",
+			Value:  "resource.appvia.io/default",
+		},
+		cli.StringFlag{
+			Name:   "region",
+			Usage:  "the cloud region the stacks reside in `NAME`",
+			EnvVar: "AWS_REGION",
+		},
+	}
+	app.Commands = []cli.Command{
+		describeCommand(),
+		logsCommand(),
+		treeCommand(),
+		reconcileCommand(),
+		diffCommand(),
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "[error] %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// makeClients is responsible for creating the kubernetes and cloud clients from the global flags
+func makeClients(cx *cli.Context) (*clients, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = cx.GlobalString("kubeconfig")
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{
+		CurrentContext: cx.GlobalString("context"),
+	})
+
+	namespace := cx.GlobalString("namespace")
+	if namespace == "" {
+		current, _, err := config.Namespace()
+		if err != nil {
+			return nil, err
+		}
+		namespace = current
+	}
+	cfg, err := config.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &clients{namespace: namespace, provider: cx.GlobalString("provider-name")}
+	if c.client, err = kubernetes.NewForConfig(cfg); err != nil {
+		return nil, err
+	}
+	if c.resources, err = versioned.NewForConfig(cfg); err != nil {
+		return nil, err
+	}
+	// @step: the stack events and outputs are optional, the kubernetes objects are shown regardless
+	cluster := cx.GlobalString("cluster")
+	if cluster == "" {
+		cluster = pluginName
+	}
+	c.cloud, c.cloudErr = aws.New(&models.ProviderConfig{
+		ClusterName: cluster,
+		Name:        cx.GlobalString("provider-name"),
+		Region:      cx.GlobalString("region"),
+	})
+
+	return c, nil
+}
+
+// getResourceName returns the name of the resource from the arguments
+func getResourceName(cx *cli.Context) (string, error) {
+	if cx.NArg() != 1 {
+		return "", fmt.Errorf("you must specify the name of the cloud resource")
+	}
+
+	return cx.Args().First(), nil
+}
+
+// signalContext returns a context cancelled on an interrupt
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		signalChannel := make(chan os.Signal, 1)
+		signal.Notify(signalChannel, syscall.SIGINT, syscall.SIGTERM)
+		<-signalChannel
+		cancel()
+	}()
+
+	return ctx, cancel
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// reconcileCommand returns the command to force the reconciliation of a cloud resource
+func reconcileCommand() cli.Command {
+	return cli.Command{
+		Name:      "reconcile",
+		Usage:     "forces the controller to update the stack of the cloud resource even if nothing has changed",
+		ArgsUsage: "NAME",
+		Action: func(cx *cli.Context) error {
+			name, err := getResourceName(cx)
+			if err != nil {
+				return err
+			}
+			c, err := makeClients(cx)
+			if err != nil {
+				return err
+			}
+			request := time.Now().UTC().Format(time.RFC3339)
+
+			if err := c.reconcile(name, request); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "cloudresource/%s reconcile requested at %s\n", name, request)
+
+			return nil
+		},
+	}
+}
+
+// reconcile sets the force reconcile annotation on the resource
+func (c *clients) reconcile(name, request string) error {
+	return utils.Retry(3, time.Second, func() error {
+		resource, err := c.resources.CloudV1().CloudResources(c.namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if resource.Annotations == nil {
+			resource.Annotations = make(map[string]string, 0)
+		}
+		resource.Annotations[models.ReconcileAnnotation] = request
+		_, err = c.resources.CloudV1().CloudResources(c.namespace).Update(resource)
+
+		return err
+	})
+}
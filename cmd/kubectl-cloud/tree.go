@@ -0,0 +1,157 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// node is an object in the tree
+type node struct {
+	// label is the description of the object
+	label string
+	// children are the objects the object depends on or generated
+	children []*node
+}
+
+// add appends a child to the node, returning the child
+func (n *node) add(format string, args ...interface{}) *node {
+	child := &node{label: fmt.Sprintf(format, args...)}
+	n.children = append(n.children, child)
+
+	return child
+}
+
+// write renders the tree
+func (n *node) write(out io.Writer, prefix string) {
+	for i, x := range n.children {
+		branch, indent := "├── ", "│   "
+		if i == len(n.children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(out, "%s%s%s\n", prefix, branch, x.label)
+		x.write(out, prefix+indent)
+	}
+}
+
+// treeCommand returns the command to show the dependencies and generated objects of a cloud resource
+func treeCommand() cli.Command {
+	return cli.Command{
+		Name:      "tree",
+		Usage:     "shows the templates and fragments the cloud resource depends on and the objects generated for it",
+		ArgsUsage: "NAME",
+		Action: func(cx *cli.Context) error {
+			name, err := getResourceName(cx)
+			if err != nil {
+				return err
+			}
+			c, err := makeClients(cx)
+			if err != nil {
+				return err
+			}
+
+			return c.tree(context.Background(), os.Stdout, name)
+		},
+	}
+}
+
+// tree writes the dependencies and generated objects of the cloud resource
+func (c *clients) tree(ctx context.Context, out io.Writer, name string) error {
+	resource, err := c.resources.CloudV1().CloudResources(c.namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	root := &node{}
+	top := root.add("CloudResource/%s/%s", resource.Namespace, resource.Name)
+
+	// @step: add the template along with the bases and fragments it is composed from
+	c.addTemplate(top, resource.Spec.TemplateName, make(map[string]bool, 0))
+
+	// @step: add the objects generated by the controller
+	status, err := c.resources.CloudV1().CloudStatuses(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	switch {
+	case err != nil:
+		top.add("CloudStatus/%s/%s (%s)", resource.Namespace, resource.Name, describeError(err))
+	default:
+		top.add("CloudStatus/%s/%s (%s)", resource.Namespace, resource.Name, getValue(status.Status, "Unknown"))
+	}
+
+	stackname := models.GetStackName(resource.Name, resource.Namespace)
+	switch {
+	case c.cloud == nil:
+		top.add("Stack/%s (unavailable: %s)", stackname, c.cloudErr)
+	default:
+		stack, err := c.cloud.Get(ctx, stackname, &models.GetOptions{})
+		if err != nil {
+			top.add("Stack/%s (%s)", stackname, describeError(err))
+			break
+		}
+		top.add("Stack/%s (%s)", stackname, getValue(stack.Status.Phase, "Unknown"))
+	}
+
+	for _, x := range resource.Spec.Secrets {
+		_, err := c.client.CoreV1().Secrets(resource.Namespace).Get(x.Name, metav1.GetOptions{})
+		top.add("Secret/%s/%s (%s)", resource.Namespace, x.Name, describeError(err))
+	}
+	root.write(out, "")
+
+	return nil
+}
+
+// addTemplate adds the template, its base templates and fragments to the tree
+func (c *clients) addTemplate(parent *node, name string, visited map[string]bool) {
+	if visited[name] {
+		parent.add("CloudTemplate/%s (cycle)", name)
+		return
+	}
+	visited[name] = true
+
+	template, err := c.resources.CloudV1().CloudTemplates().Get(name, metav1.GetOptions{})
+	if err != nil {
+		parent.add("CloudTemplate/%s (%s)", name, describeError(err))
+		return
+	}
+	child := parent.add("CloudTemplate/%s (%s)", name, getValue(template.Status.Status, "Unknown"))
+	if template.Spec.Extends != "" {
+		c.addTemplate(child, template.Spec.Extends, visited)
+	}
+	for _, x := range template.Spec.Fragments {
+		_, err := c.resources.CloudV1().CloudTemplateFragments().Get(x, metav1.GetOptions{})
+		child.add("CloudTemplateFragment/%s (%s)", x, describeError(err))
+	}
+}
+
+// describeError returns a short description of the result of retrieving an object
+func describeError(err error) string {
+	switch {
+	case err == nil:
+		return "Found"
+	case kerrors.IsNotFound(err), err == models.ErrStackNotFound:
+		return "NotFound"
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// checksumCommand returns the command to print the stack checksum of the resources
+func checksumCommand() cli.Command {
+	return cli.Command{
+		Name:  "checksum",
+		Usage: "prints the stack name and checksum the controller would tag the stacks of the resources with",
+		Flags: []cli.Flag{
+			fileFlag,
+			cli.StringFlag{
+				Name:  "resource,r",
+				Usage: "the name of the resource, defaults to all resources in the files `NAME`",
+			},
+		},
+		Action: func(cx *cli.Context) error {
+			manifests, err := readManifests(cx)
+			if err != nil {
+				return err
+			}
+			resources := manifests.Resources
+			if name := cx.String("resource"); name != "" {
+				resource, err := findResource(manifests, name)
+				if err != nil {
+					return err
+				}
+				resources = []*apiv1.CloudResource{resource}
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "NAMESPACE\tNAME\tSTACK\tCHECKSUM")
+			for _, x := range resources {
+				namespace := x.Namespace
+				if namespace == "" {
+					namespace = "default"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", namespace, x.Name, models.GetStackName(x.Name, namespace), models.GetResourceChecksum(x))
+			}
+
+			return w.Flush()
+		},
+	}
+}
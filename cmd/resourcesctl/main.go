@@ -0,0 +1,57 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/gambol99/resources/pkg/version"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Usage = "renders, validates and inspects cloud templates and resources without access to a cluster or cloud"
+	app.Version = version.GetVersion()
+	app.Author = version.Author
+	app.Email = version.Email
+	app.Compiled = version.GetBuildTime()
+	app.Commands = []cli.Command{
+		renderCommand(),
+		validateCommand(),
+		checksumCommand(),
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "[error] %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// fileFlag is the flag for the yaml files holding the templates, fragments and resources
+var fileFlag = cli.StringSliceFlag{
+	Name:  "file,f",
+	Usage: "a yaml file containing cloud templates, fragments or resources `PATH`",
+}
+
+// templatesDirFlag is the flag for the directory of template content
+var templatesDirFlag = cli.StringFlag{
+	Name:  "templates-dir",
+	Usage: "an optional directory of template content referenced by the templates `PATH`",
+}
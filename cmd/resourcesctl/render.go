@@ -0,0 +1,178 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/urfave/cli"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/cloud/aws"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// fixture provides the values the controller would otherwise retrieve from the cluster or cloud
+type fixture struct {
+	// Cluster is the name of the cluster presented to the templates
+	Cluster string `json:"cluster,omitempty"`
+	// Discovery are the stubbed values of the discovery lookups
+	Discovery *apiv1.DiscoveryStubs `json:"discovery,omitempty"`
+	// NamespaceLabels are the labels on the namespace of the resource
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+	// Secrets are the kubernetes secrets referenced by the resource parameters
+	Secrets map[string]map[string]string `json:"secrets,omitempty"`
+}
+
+// renderCommand returns the command to render the template of a resource
+func renderCommand() cli.Command {
+	return cli.Command{
+		Name:  "render",
+		Usage: "renders the cloud template of a resource as the controller would, with discovery stubbed from a fixture",
+		Flags: []cli.Flag{
+			fileFlag,
+			templatesDirFlag,
+			cli.StringFlag{
+				Name:  "resource,r",
+				Usage: "the name of the resource to render, required when the files contain multiple resources `NAME`",
+			},
+			cli.StringFlag{
+				Name:  "fixture",
+				Usage: "an optional yaml file providing the cluster, discovery values, namespace labels and secrets `PATH`",
+			},
+		},
+		Action: func(cx *cli.Context) error {
+			manifests, err := readManifests(cx)
+			if err != nil {
+				return err
+			}
+			resource, err := findResource(manifests, cx.String("resource"))
+			if err != nil {
+				return err
+			}
+			fixture, err := readFixture(cx.String("fixture"))
+			if err != nil {
+				return err
+			}
+			rendered, err := renderResource(manifests, utils.NewContentLoader(nil, cx.String("templates-dir")), resource, fixture)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(os.Stdout, rendered)
+
+			return nil
+		},
+	}
+}
+
+// renderResource builds the model of the resource and renders the template in the same way as
+// the resources controller, without calling the kubernetes or cloud apis
+func renderResource(manifests *utils.Manifests, loader *utils.ContentLoader, resource *apiv1.CloudResource, fixture *fixture) (string, error) {
+	template, err := manifests.Template(resource.Spec.TemplateName)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := manifests.Resolve(loader, template)
+	if err != nil {
+		return "", err
+	}
+
+	if errs := resource.IsValid(); len(errs) > 0 {
+		return "", utils.GetErrors(errs)
+	}
+	if errs := resolved.Template.IsValid(); len(errs) > 0 {
+		return "", utils.GetErrors(errs)
+	}
+
+	model, err := models.MakeResourceModel(resolved.Template, resource, func(name, namespace string) (map[string]string, error) {
+		secret, found := fixture.Secrets[name]
+		if !found {
+			return nil, fmt.Errorf("secret: %s not found in the fixture", name)
+		}
+
+		return secret, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.RenderWithStubs(context.Background(), &models.ProviderConfig{ClusterName: fixture.Cluster}, &models.CreateOptions{
+		Context:         model,
+		NamespaceLabels: fixture.NamespaceLabels,
+		Partials:        resolved.Partials,
+		Resource:        resource,
+		Template:        resolved.Template,
+	}, fixture.Discovery)
+}
+
+// readManifests reads the cloud objects from the files given on the command line
+func readManifests(cx *cli.Context) (*utils.Manifests, error) {
+	if len(cx.StringSlice("file")) <= 0 {
+		return nil, fmt.Errorf("no files specified")
+	}
+
+	return utils.ReadManifests(cx.StringSlice("file")...)
+}
+
+// findResource returns the named resource, or the only resource when no name is given
+func findResource(manifests *utils.Manifests, name string) (*apiv1.CloudResource, error) {
+	var resource *apiv1.CloudResource
+	switch {
+	case name != "":
+		for _, x := range manifests.Resources {
+			if x.Name == name {
+				resource = x
+			}
+		}
+		if resource == nil {
+			return nil, fmt.Errorf("cloud resource: %s does not exist", name)
+		}
+	case len(manifests.Resources) == 1:
+		resource = manifests.Resources[0]
+	case len(manifests.Resources) == 0:
+		return nil, fmt.Errorf("no cloud resources found in the files")
+	default:
+		return nil, fmt.Errorf("multiple cloud resources found, please specify the resource")
+	}
+	if resource.Namespace == "" {
+		resource.Namespace = "default"
+	}
+
+	return resource, nil
+}
+
+// readFixture reads the fixture file, an empty fixture is returned when no file is given
+func readFixture(path string) (*fixture, error) {
+	fixture := &fixture{}
+	if path == "" {
+		return fixture, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(content, fixture); err != nil {
+		return nil, fmt.Errorf("unable to parse the fixture: %s, error: %s", path, err)
+	}
+
+	return fixture, nil
+}
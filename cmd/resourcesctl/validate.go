@@ -0,0 +1,111 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// validateCommand returns the command to validate the templates, fragments and resources
+func validateCommand() cli.Command {
+	return cli.Command{
+		Name:  "validate",
+		Usage: "validates the cloud templates, fragments and resources, checking the resource parameters against the templates",
+		Flags: []cli.Flag{
+			fileFlag,
+			templatesDirFlag,
+		},
+		Action: func(cx *cli.Context) error {
+			manifests, err := readManifests(cx)
+			if err != nil {
+				return err
+			}
+			failed := validateManifests(manifests, utils.NewContentLoader(nil, cx.String("templates-dir")))
+			if failed > 0 {
+				return fmt.Errorf("%d objects failed validation", failed)
+			}
+
+			return nil
+		},
+	}
+}
+
+// validateManifests prints the result of validating each object, returning the number which failed
+func validateManifests(manifests *utils.Manifests, loader *utils.ContentLoader) int {
+	var failed int
+
+	report := func(kind, name string, reasons []string) {
+		if len(reasons) <= 0 {
+			fmt.Fprintf(os.Stdout, "OK   %s/%s\n", kind, name)
+			return
+		}
+		failed++
+		fmt.Fprintf(os.Stdout, "FAIL %s/%s\n", kind, name)
+		for _, x := range reasons {
+			fmt.Fprintf(os.Stdout, "    %s\n", x)
+		}
+	}
+
+	for _, x := range manifests.Fragments {
+		report("fragment", x.Name, getReasons(x.IsValid()))
+	}
+
+	for _, x := range manifests.Templates {
+		reasons := getReasons(x.IsValid())
+		// @check the content, bases and fragments of the template can be resolved
+		if _, err := manifests.Resolve(loader, x); err != nil {
+			reasons = append(reasons, err.Error())
+		}
+		report("template", x.Name, reasons)
+	}
+
+	for _, x := range manifests.Resources {
+		reasons := getReasons(x.IsValid())
+		// @check the parameters and secrets of the resource against the template
+		template, err := manifests.Template(x.Spec.TemplateName)
+		if err == nil {
+			var resolved *models.ResolvedTemplate
+			if resolved, err = manifests.Resolve(loader, template); err == nil {
+				reasons = append(reasons, getReasons(models.ValidateResourceParameters(x, resolved.Template))...)
+				reasons = append(reasons, models.CheckSecretMappings(x, resolved.Template)...)
+			}
+		}
+		if err != nil {
+			reasons = append(reasons, err.Error())
+		}
+		report("resource", x.Name, reasons)
+	}
+
+	return failed
+}
+
+// getReasons returns the validation errors as a list of messages
+func getReasons(errs field.ErrorList) []string {
+	var list []string
+	for _, x := range errs {
+		list = append(list, x.Error())
+	}
+
+	return list
+}
@@ -17,6 +17,12 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -102,6 +108,73 @@ func (p *Parameter) IsValid(path *field.Path, allowEmpty bool) field.ErrorList {
 	return errs
 }
 
+// GetMissingParameters returns the parameters required by the template, i.e. have no default,
+// which have not been set on the resource
+func (c *CloudResource) GetMissingParameters(template *CloudTemplate) []string {
+	var list []string
+	for _, x := range template.Spec.Parameters {
+		if x.Value == nil && !c.HasParameter(x.Name) {
+			list = append(list, x.Name)
+		}
+	}
+
+	return list
+}
+
+// ApplyDefaults copies the retention and deletion policy from the template when not set on the
+// resource, returning true if the resource was changed
+func (c *CloudResource) ApplyDefaults(template *CloudTemplate) bool {
+	var changed bool
+
+	if c.Spec.Retention == nil && template.Spec.Retention != nil {
+		c.Spec.Retention = template.Spec.Retention.DeepCopy()
+		changed = true
+	}
+	if c.Spec.DeleteOn == nil && template.Spec.DeleteOn != nil {
+		policy := *template.Spec.DeleteOn
+		c.Spec.DeleteOn = &policy
+		changed = true
+	}
+
+	return changed
+}
+
+// GetEngine returns the rendering engine of the template, defaulting to go templates
+func (t *TemplateSpec) GetEngine() string {
+	if t.Engine == "" {
+		return EngineGoTemplate
+	}
+
+	return t.Engine
+}
+
+// isValidEngine checks the rendering engine is supported, empty being the default
+func isValidEngine(engine string) bool {
+	if engine == "" {
+		return true
+	}
+	for _, x := range Engines {
+		if x == engine {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValidDeleteOn checks the deletion policy is supported
+func isValidDeleteOn(path *field.Path, policy *string) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	switch *policy {
+	case DeleteOnRetention, DeleteNever:
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(path, *policy, "unsupported deletion policy")}
+}
+
 // IsValid checks the cloud resource is valid
 func (c *CloudResource) IsValid() field.ErrorList {
 	var errs field.ErrorList
@@ -109,40 +182,368 @@ func (c *CloudResource) IsValid() field.ErrorList {
 	if c.Spec.TemplateName == "" {
 		errs = append(errs, field.Invalid(field.NewPath("spec").Key("templateName"), c.Spec.TemplateName, "no template name defined"))
 	}
+	errs = append(errs, isValidDeleteOn(field.NewPath("spec").Key("deleteOn"), c.Spec.DeleteOn)...)
 	for i, x := range c.Spec.Parameters {
 		errs = append(errs, x.IsValid(field.NewPath("spec").Key("parameters").Index(i), false)...)
 	}
 	for i, x := range c.Spec.Secrets {
 		errs = append(errs, x.IsValid(field.NewPath("spec").Key("secrets").Index(i))...)
 	}
+	errs = append(errs, isValidTimeout(field.NewPath("spec").Key("timeout"), c.Spec.Timeout)...)
+	if c.Spec.TimeoutAction != "" && !IsValidTimeoutAction(c.Spec.TimeoutAction) {
+		errs = append(errs, field.Invalid(field.NewPath("spec").Key("timeoutAction"), c.Spec.TimeoutAction, "unsupported timeout action"))
+	}
+	if c.Spec.SupersedePolicy != "" && !IsValidSupersedePolicy(c.Spec.SupersedePolicy) {
+		errs = append(errs, field.Invalid(field.NewPath("spec").Key("supersedePolicy"), c.Spec.SupersedePolicy, "unsupported supersede policy"))
+	}
 
 	return errs
 }
 
+// IsValidTimeoutAction checks the action on a timed out operation is supported
+func IsValidTimeoutAction(action string) bool {
+	for _, x := range TimeoutActions {
+		if x == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValidSupersedePolicy checks the policy on the resource changing during an operation is supported
+func IsValidSupersedePolicy(policy string) bool {
+	for _, x := range SupersedePolicies {
+		if x == policy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValidTimeout checks the timeout is positive when set
+func isValidTimeout(path *field.Path, timeout *metav1.Duration) field.ErrorList {
+	if timeout == nil || timeout.Duration > 0 {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(path, timeout.Duration.String(), "timeout must be positive")}
+}
+
 // IsValid checks the template is valid
 func (c *CloudTemplate) IsValid() field.ErrorList {
 	var errs field.ErrorList
 
 	spec := field.NewPath("spec")
 
-	if c.Spec.Content == "" {
-		errs = append(errs, field.Invalid(spec.Key("content"), c.Spec.Content, "no stack template specified"))
+	// @check a template extending a base can inherit the content, retention and format
+	if c.Spec.Extends == "" {
+		if c.Spec.Content == "" && c.Spec.ContentFrom == nil {
+			errs = append(errs, field.Invalid(spec.Key("content"), c.Spec.Content, "no stack template specified"))
+		}
+		if c.Spec.Retention == nil {
+			errs = append(errs, field.Invalid(spec.Key("retention"), c.Spec.Content, "no retention policy defined"))
+		}
+		if c.Spec.Format == "" {
+			errs = append(errs, field.Invalid(spec.Key("format"), c.Spec.Format, "no format defined"))
+		}
 	}
-	if c.Spec.Retention == nil {
-		errs = append(errs, field.Invalid(spec.Key("retention"), c.Spec.Content, "no retention policy defined"))
+	if c.Spec.ContentFrom != nil {
+		if c.Spec.Content != "" {
+			errs = append(errs, field.Invalid(spec.Key("contentFrom"), "", "content and contentFrom are mutually exclusive"))
+		}
+		errs = append(errs, c.Spec.ContentFrom.IsValid(spec.Key("contentFrom"))...)
 	}
-	if c.Spec.Format == "" {
-		errs = append(errs, field.Invalid(spec.Key("format"), c.Spec.Format, "no format defined"))
+	if c.Spec.Extends == c.Name && c.Name != "" {
+		errs = append(errs, field.Invalid(spec.Key("extends"), c.Spec.Extends, "template cannot extend itself"))
 	}
-	if c.Spec.Format != FormatJSON && c.Spec.Format != FormatYAML {
+	if c.Spec.Format != "" && c.Spec.Format != FormatJSON && c.Spec.Format != FormatYAML {
 		errs = append(errs, field.Invalid(spec.Key("format"), c.Spec.Format, "unsupported format"))
 	}
+	if !isValidEngine(c.Spec.Engine) {
+		errs = append(errs, field.Invalid(spec.Key("engine"), c.Spec.Engine, "unsupported rendering engine"))
+	}
+	if c.Spec.GetEngine() != EngineGoTemplate && (c.Spec.Extends != "" || len(c.Spec.Fragments) > 0) {
+		errs = append(errs, field.Invalid(spec.Key("engine"), c.Spec.Engine, "extends and fragments are only supported by the gotemplate engine"))
+	}
+	for i, x := range c.Spec.Fragments {
+		if x == "" {
+			errs = append(errs, field.Invalid(spec.Key("fragments").Index(i), x, "no fragment name defined"))
+		}
+	}
+	errs = append(errs, isValidDeleteOn(spec.Key("deleteOn"), c.Spec.DeleteOn)...)
+	errs = append(errs, isValidTimeout(spec.Key("timeout"), c.Spec.Timeout)...)
 	for i, x := range c.Spec.Parameters {
 		errs = append(errs, x.IsValid(spec.Key("parameters").Index(i), true)...)
+		for _, name := range ReservedParameters {
+			if x.Name == name {
+				errs = append(errs, field.Invalid(spec.Key("parameters").Index(i).Key("name"), x.Name, "parameter name is reserved"))
+			}
+		}
 	}
 	for i, x := range c.Spec.Secrets {
 		errs = append(errs, x.IsValid(spec.Key("secrets").Index(i))...)
 	}
+	names := make(map[string]bool, 0)
+	for i, x := range c.Spec.Tests {
+		item := spec.Key("tests").Index(i)
+		if x.Name == "" {
+			errs = append(errs, field.Invalid(item.Key("name"), x.Name, "no test name defined"))
+		}
+		if names[x.Name] {
+			errs = append(errs, field.Invalid(item.Key("name"), x.Name, "test name is defined more than once"))
+		}
+		names[x.Name] = true
+		for j, a := range x.Assertions {
+			if a.Path == "" {
+				errs = append(errs, field.Invalid(item.Key("assertions").Index(j).Key("path"), a.Path, "no jsonpath defined"))
+			}
+		}
+	}
+	if c.Spec.Successor != nil {
+		errs = append(errs, c.Spec.Successor.IsValid(spec.Key("successor"), c.Name)...)
+		if !c.Spec.Deprecated {
+			errs = append(errs, field.Invalid(spec.Key("successor"), c.Spec.Successor.Name, "successor requires the template to be deprecated"))
+		}
+	}
+
+	return errs
+}
+
+// IsValid checks the successor of a deprecated template is valid
+func (s *TemplateSuccessor) IsValid(path *field.Path, name string) field.ErrorList {
+	var errs field.ErrorList
+
+	if s.Name == "" {
+		errs = append(errs, field.Invalid(path.Key("name"), s.Name, "no successor template name defined"))
+	}
+	if s.Name == name && name != "" {
+		errs = append(errs, field.Invalid(path.Key("name"), s.Name, "template cannot be its own successor"))
+	}
+	targets := make(map[string]bool, 0)
+	for i, x := range s.Parameters {
+		item := path.Key("parameters").Index(i)
+		if x.To == "" {
+			errs = append(errs, field.Invalid(item.Key("to"), x.To, "no successor parameter name defined"))
+		}
+		if targets[x.To] {
+			errs = append(errs, field.Invalid(item.Key("to"), x.To, "successor parameter is mapped more than once"))
+		}
+		targets[x.To] = true
+		if (x.From == "") == (x.Value == nil) {
+			errs = append(errs, field.Invalid(item, x.From, "exactly one of from or value must be defined"))
+		}
+	}
 
 	return errs
 }
+
+// GetDeprecationWarning returns a warning for resources using the template, empty if the template is
+// not deprecated
+func (c *CloudTemplate) GetDeprecationWarning() string {
+	if !c.Spec.Deprecated {
+		return ""
+	}
+	warning := fmt.Sprintf("cloud template: %s is deprecated", c.Name)
+	if c.Spec.DeprecationMessage != "" {
+		warning = fmt.Sprintf("%s, %s", warning, c.Spec.DeprecationMessage)
+	}
+	if c.Spec.Successor != nil {
+		warning = fmt.Sprintf("%s, migrate to: %s", warning, c.Spec.Successor.Name)
+	}
+
+	return warning
+}
+
+// IsValid checks the content source is valid
+func (c *ContentSource) IsValid(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	switch {
+	case c.ConfigMapKeyRef == nil && c.File == "":
+		errs = append(errs, field.Invalid(path, "", "no content source defined"))
+	case c.ConfigMapKeyRef != nil && c.File != "":
+		errs = append(errs, field.Invalid(path, "", "only one content source can be defined"))
+	case c.ConfigMapKeyRef != nil:
+		ref := path.Key("configMapKeyRef")
+		if c.ConfigMapKeyRef.Name == "" {
+			errs = append(errs, field.Invalid(ref.Key("name"), "", "no configmap name defined"))
+		}
+		if c.ConfigMapKeyRef.Namespace == "" {
+			errs = append(errs, field.Invalid(ref.Key("namespace"), "", "no configmap namespace defined"))
+		}
+		if c.ConfigMapKeyRef.Key == "" {
+			errs = append(errs, field.Invalid(ref.Key("key"), "", "no configmap key defined"))
+		}
+	default:
+		if filepath.IsAbs(c.File) || strings.HasPrefix(filepath.Clean(c.File), "..") {
+			errs = append(errs, field.Invalid(path.Key("file"), c.File, "file must be relative to the templates directory"))
+		}
+	}
+
+	return errs
+}
+
+// IsValid checks the template fragment is valid
+func (c *CloudTemplateFragment) IsValid() field.ErrorList {
+	var errs field.ErrorList
+
+	spec := field.NewPath("spec")
+	if c.Spec.Content == "" {
+		errs = append(errs, field.Invalid(spec.Key("content"), c.Spec.Content, "no fragment content specified"))
+	}
+
+	return errs
+}
+
+// IsValid checks the cloud policy is valid
+func (c *CloudPolicy) IsValid() field.ErrorList {
+	var errs field.ErrorList
+
+	spec := field.NewPath("spec")
+	for i, x := range c.Spec.Constraints {
+		errs = append(errs, x.IsValid(spec.Key("constraints").Index(i))...)
+	}
+
+	return errs
+}
+
+// IsValid checks the policy constraint is valid
+func (p *PolicyConstraint) IsValid(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if p.Name == "" {
+		errs = append(errs, field.Invalid(path.Key("name"), p.Name, "no name defined"))
+	}
+	if p.Path == "" {
+		errs = append(errs, field.Invalid(path.Key("path"), p.Path, "no path defined"))
+	}
+	switch p.Operator {
+	case OperatorExists, OperatorNotExists:
+	case OperatorEquals, OperatorNotEquals, OperatorIn, OperatorNotIn, OperatorMatches, OperatorLessThan, OperatorGreaterThan:
+		if len(p.Values) <= 0 {
+			errs = append(errs, field.Invalid(path.Key("values"), "", "no values defined for operator"))
+		}
+	default:
+		errs = append(errs, field.Invalid(path.Key("operator"), p.Operator, "unsupported operator"))
+	}
+
+	return errs
+}
+
+// IsValid checks the cloud quota is valid
+func (c *CloudQuota) IsValid() field.ErrorList {
+	var errs field.ErrorList
+
+	spec := field.NewPath("spec")
+	if c.Spec.MaxStacks != nil && *c.Spec.MaxStacks < 0 {
+		errs = append(errs, field.Invalid(spec.Key("maxStacks"), *c.Spec.MaxStacks, "must be zero or greater"))
+	}
+	for i, x := range c.Spec.Templates {
+		path := spec.Key("templates").Index(i)
+		if x.Name == "" {
+			errs = append(errs, field.Invalid(path.Key("name"), x.Name, "no template name defined"))
+		}
+		if x.MaxResources < 0 {
+			errs = append(errs, field.Invalid(path.Key("maxResources"), x.MaxResources, "must be zero or greater"))
+		}
+	}
+
+	return errs
+}
+
+// IsTemplateAllowed checks if the template is permitted by the quota
+func (c *CloudQuota) IsTemplateAllowed(name string) bool {
+	if len(c.Spec.AllowedTemplates) <= 0 {
+		return true
+	}
+	for _, x := range c.Spec.AllowedTemplates {
+		if x == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetTemplateLimit returns the limit for a template if one is defined
+func (c *CloudQuota) GetTemplateLimit(name string) (int, bool) {
+	for _, x := range c.Spec.Templates {
+		if x.Name == name {
+			return x.MaxResources, true
+		}
+	}
+
+	return 0, false
+}
+
+// GetCondition returns the condition of the type if present
+func (c *CloudStatus) GetCondition(kind string) (Condition, bool) {
+	for _, x := range c.Conditions {
+		if x.Type == kind {
+			return x, true
+		}
+	}
+
+	return Condition{}, false
+}
+
+// SetCondition adds or replaces the condition of the same type, retaining the
+// transition time if the status of the condition has not changed
+func (c *CloudStatus) SetCondition(condition Condition) {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	for i, x := range c.Conditions {
+		if x.Type != condition.Type {
+			continue
+		}
+		if x.Status == condition.Status {
+			condition.LastTransitionTime = x.LastTransitionTime
+		}
+		c.Conditions[i] = condition
+
+		return
+	}
+
+	c.Conditions = append(c.Conditions, condition)
+}
+
+// HasChanges checks if the deployed stack differs from the rendered template or tags
+func (s *StackDiff) HasChanges() bool {
+	if s == nil {
+		return false
+	}
+
+	return s.Template != "" || len(s.Tags) > 0
+}
+
+// HasExpired checks if the operation has exceeded its timeout and the timeout action is yet to be taken
+func (o *StackOperation) HasExpired(now time.Time) bool {
+	if o == nil || o.Timeout == nil || o.TimeoutAction != "" {
+		return false
+	}
+
+	return now.After(o.StartTime.Add(o.Timeout.Duration))
+}
+
+// checkpointSteps are the steps of a reconcile in the order they are completed
+var checkpointSteps = []string{CheckpointStackApplied, CheckpointCredentialsMinted, CheckpointSecretsWritten}
+
+// Reached checks if the checkpoint is at or beyond the step
+func (c *ReconcileCheckpoint) Reached(step string) bool {
+	if c == nil {
+		return false
+	}
+	position := func(name string) int {
+		for i, x := range checkpointSteps {
+			if x == name {
+				return i
+			}
+		}
+		return len(checkpointSteps)
+	}
+
+	return position(c.Step) < len(checkpointSteps) && position(c.Step) >= position(step)
+}
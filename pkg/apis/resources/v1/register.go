@@ -49,9 +49,15 @@ func init() {
 // Adds the list of known types to api.Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&CloudPolicyList{},
+		&CloudPolicy{},
+		&CloudQuotaList{},
+		&CloudQuota{},
 		&CloudResourceList{},
 		&CloudResource{},
 		&CloudStatus{},
+		&CloudTemplateFragmentList{},
+		&CloudTemplateFragment{},
 		&CloudTemplateList{},
 		&CloudTemplate{},
 	)
@@ -34,6 +34,29 @@ const (
 	DeleteNever = "never"
 )
 
+const (
+	// TimeoutActionCancel indicates a timed out operation is cancelled, rolling back the stack
+	TimeoutActionCancel = "Cancel"
+	// TimeoutActionFail indicates a timed out operation is left running but the resource is marked as failed
+	TimeoutActionFail = "Fail"
+	// TimeoutActionLeave indicates a timed out operation is left running
+	TimeoutActionLeave = "Leave"
+)
+
+const (
+	// SupersedeCancel indicates an update in progress is cancelled when the resource changes again
+	SupersedeCancel = "Cancel"
+	// SupersedeCoalesce indicates an update in progress is left to complete, with only the newest
+	// specification of the resource applied after
+	SupersedeCoalesce = "Coalesce"
+)
+
+// SupersedePolicies is a list of the supported policies on a resource changing during an operation
+var SupersedePolicies = []string{SupersedeCancel, SupersedeCoalesce}
+
+// TimeoutActions is a list of supported actions on a timed out operation
+var TimeoutActions = []string{TimeoutActionCancel, TimeoutActionFail, TimeoutActionLeave}
+
 const (
 	// SecretTypeOutput indicates an output
 	SecretTypeOutput = "output"
@@ -97,6 +120,179 @@ type CloudStatus struct {
 	// Logs are the logs from from the stack
 	// +optional
 	Logs string `json:"logs,omitempty" protobuf:"bytes,5,opt,name=reason"`
+	// Conditions are the current observed conditions of the resource
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty" protobuf:"bytes,6,rep,name=conditions"`
+	// Diff is the difference between the deployed stack and the resource as it would render now
+	// +optional
+	Diff *StackDiff `json:"diff,omitempty" protobuf:"bytes,7,opt,name=diff"`
+	// Operation is the operation in progress on the stack, polled until it completes
+	// +optional
+	Operation *StackOperation `json:"operation,omitempty" protobuf:"bytes,8,opt,name=operation"`
+	// Checkpoint is the last step completed by the reconcile of the stack
+	// +optional
+	Checkpoint *ReconcileCheckpoint `json:"checkpoint,omitempty" protobuf:"bytes,9,opt,name=checkpoint"`
+	// Stack is the state of the stack in the cloud provider
+	// +optional
+	Stack *StackState `json:"stack,omitempty" protobuf:"bytes,10,opt,name=stack"`
+}
+
+// StackState is the state of the stack in the cloud provider
+type StackState struct {
+	// Phase is the phase of the stack i.e. Creating, Updating, Ready, RollingBack, RolledBack, Failed,
+	// Deleting or Deleted
+	// +required
+	Phase string `json:"phase" protobuf:"bytes,1,opt,name=phase"`
+	// ProviderStatus is the raw status of the stack in the cloud provider
+	// +optional
+	ProviderStatus string `json:"providerStatus,omitempty" protobuf:"bytes,2,opt,name=providerStatus"`
+	// Reason is the reason given by the cloud provider for the status
+	// +optional
+	Reason string `json:"reason,omitempty" protobuf:"bytes,3,opt,name=reason"`
+	// LastTransitionTime is the time the stack last changed status
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+}
+
+const (
+	// OperationCreate indicates the stack is being created
+	OperationCreate = "Create"
+	// OperationUpdate indicates the stack is being updated
+	OperationUpdate = "Update"
+	// OperationWait indicates the stack was found in progress, i.e. the operation was not started by us
+	OperationWait = "Wait"
+)
+
+// StackOperation is an operation started on the stack of the resource
+type StackOperation struct {
+	// ID is the unique id of the operation, passed to the cloud as the request token
+	// +optional
+	ID string `json:"id,omitempty" protobuf:"bytes,1,opt,name=id"`
+	// Type is the type of operation, i.e. Create, Update or Wait
+	// +required
+	Type string `json:"type" protobuf:"bytes,2,opt,name=type"`
+	// StartTime is the time the operation was started
+	// +required
+	StartTime metav1.Time `json:"startTime" protobuf:"bytes,3,opt,name=startTime"`
+	// Timeout is the duration the operation may take, no timeout when not set
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,4,opt,name=timeout"`
+	// TimeoutAction is the action taken once the operation exceeded the timeout i.e. Cancel, Leave or Fail
+	// +optional
+	TimeoutAction string `json:"timeoutAction,omitempty" protobuf:"bytes,5,opt,name=timeoutAction"`
+	// Checksum is the checksum of the resource the operation is applying
+	// +optional
+	Checksum string `json:"checksum,omitempty" protobuf:"bytes,6,opt,name=checksum"`
+	// Superseded records the resource changing while the operation is in progress
+	// +optional
+	Superseded *StackSupersede `json:"superseded,omitempty" protobuf:"bytes,7,opt,name=superseded"`
+}
+
+// StackSupersede records the resource changing while an operation is in progress on the stack
+type StackSupersede struct {
+	// Checksum is the checksum of the newest specification of the resource, applied once the operation completes
+	// +required
+	Checksum string `json:"checksum" protobuf:"bytes,1,opt,name=checksum"`
+	// Count is the number of changes to the resource while the operation was in progress
+	// +required
+	Count int32 `json:"count" protobuf:"varint,2,opt,name=count"`
+	// Policy is the policy applied, i.e. Cancel or Coalesce
+	// +required
+	Policy string `json:"policy" protobuf:"bytes,3,opt,name=policy"`
+	// LastTransitionTime is the time the newest specification was seen
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+}
+
+const (
+	// CheckpointStackApplied indicates the stack has been created or updated
+	CheckpointStackApplied = "StackApplied"
+	// CheckpointCredentialsMinted indicates the credentials have been generated from the stack
+	CheckpointCredentialsMinted = "CredentialsMinted"
+	// CheckpointSecretsWritten indicates the secrets have been written into the namespace
+	CheckpointSecretsWritten = "SecretsWritten"
+)
+
+// ReconcileCheckpoint is the last step completed by the reconcile of a stack; a controller taking over
+// the resource resumes from the step which follows
+type ReconcileCheckpoint struct {
+	// Checksum is the checksum of the stack the reconcile is for
+	// +required
+	Checksum string `json:"checksum" protobuf:"bytes,1,opt,name=checksum"`
+	// Reconcile is the reconcile request of the stack the reconcile is for
+	// +optional
+	Reconcile string `json:"reconcile,omitempty" protobuf:"bytes,2,opt,name=reconcile"`
+	// Step is the last step completed, i.e. StackApplied, CredentialsMinted or SecretsWritten
+	// +required
+	Step string `json:"step" protobuf:"bytes,3,opt,name=step"`
+	// LastTransitionTime is the time the step was completed
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+}
+
+// StackDiff is the difference between the deployed stack and the rendered template and tags
+type StackDiff struct {
+	// Template are the lines which differ between the normalised deployed and rendered templates
+	// +optional
+	Template string `json:"template,omitempty" protobuf:"bytes,1,opt,name=template"`
+	// Tags are the tags which differ between the deployed stack and the rendered tags
+	// +optional
+	Tags []TagDiff `json:"tags,omitempty" protobuf:"bytes,2,rep,name=tags"`
+	// LastCheckedTime is the time the stack was compared
+	// +optional
+	LastCheckedTime metav1.Time `json:"lastCheckedTime,omitempty" protobuf:"bytes,3,opt,name=lastCheckedTime"`
+}
+
+// TagDiff is a tag which differs between the deployed stack and the rendered tags
+type TagDiff struct {
+	// Key is the key of the tag
+	// +required
+	Key string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	// Deployed is the value on the stack, empty if the tag would be added
+	// +optional
+	Deployed string `json:"deployed,omitempty" protobuf:"bytes,2,opt,name=deployed"`
+	// Rendered is the value rendered now, empty if the tag would be removed
+	// +optional
+	Rendered string `json:"rendered,omitempty" protobuf:"bytes,3,opt,name=rendered"`
+}
+
+const (
+	// ConditionPolicyViolation indicates the rendered template violates a cloud policy
+	ConditionPolicyViolation = "PolicyViolation"
+	// ConditionQuotaExceeded indicates the resource would exceed the namespace quota
+	ConditionQuotaExceeded = "QuotaExceeded"
+	// ConditionTemplateDeprecated indicates the template of the resource has been deprecated
+	ConditionTemplateDeprecated = "TemplateDeprecated"
+	// ConditionTimedOut indicates an operation on the stack exceeded the timeout
+	ConditionTimedOut = "TimedOut"
+)
+
+const (
+	// ConditionTrue means the resource is in the condition
+	ConditionTrue = "True"
+	// ConditionFalse means the resource is not in the condition
+	ConditionFalse = "False"
+	// ConditionUnknown means we cannot decide if the resource is in the condition
+	ConditionUnknown = "Unknown"
+)
+
+// Condition describes the state of the resource at a certain point
+type Condition struct {
+	// Type is the type of the condition
+	// +required
+	Type string `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// Status is the status of the condition, one of True, False or Unknown
+	// +required
+	Status string `json:"status" protobuf:"bytes,2,opt,name=status"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,3,opt,name=lastTransitionTime"`
+	// A brief CamelCase message indicating details about the transition
+	// +optional
+	Reason string `json:"reason,omitempty" protobuf:"bytes,4,opt,name=reason"`
+	// A human readable message indicating details about the transition
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,5,opt,name=message"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -145,6 +341,16 @@ type CloudResourceSpec struct {
 	// Secrets is a mapping for outputs to kube secrets
 	// +optional
 	Secrets []Secret `json:"secrets,omitempty" protobuf:"bytes,5,ops,name=secrets,casttype=Secret"`
+	// Timeout is the duration an operation on the stack may take, overriding the template and controller
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,6,opt,name=timeout"`
+	// TimeoutAction is the action taken on an operation which has timed out i.e. Cancel, Leave or Fail
+	// +optional
+	TimeoutAction string `json:"timeoutAction,omitempty" protobuf:"bytes,7,opt,name=timeoutAction"`
+	// SupersedePolicy is the policy when the resource changes while an operation is in progress i.e.
+	// Cancel or Coalesce
+	// +optional
+	SupersedePolicy string `json:"supersedePolicy,omitempty" protobuf:"bytes,8,opt,name=supersedePolicy"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -181,6 +387,39 @@ type CloudTemplateList struct {
 	Items []CloudTemplate `json:"items" protobuf:"bytes,2,rep,name=items"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudTemplateFragment is a collection of named go template define blocks shared by templates
+type CloudTemplateFragment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec is the specification of the fragment
+	Spec TemplateFragmentSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudTemplateFragmentList is a list of CloudTemplateFragment items
+type CloudTemplateFragmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Items is a list of CloudTemplateFragment
+	Items []CloudTemplateFragment `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// TemplateFragmentSpec defines the specification for a template fragment
+type TemplateFragmentSpec struct {
+	// Content is the go template content containing the define blocks
+	// +required
+	Content string `json:"content" protobuf:"bytes,1,opt,name=content"`
+	// Description is a short description of the fragment
+	// +optional
+	Description string `json:"description,omitempty" protobuf:"bytes,2,opt,name=description"`
+}
+
 const (
 	// FormatYAML is the yaml template format
 	FormatYAML = "yaml"
@@ -188,6 +427,22 @@ const (
 	FormatJSON = "json"
 )
 
+const (
+	// EngineGoTemplate renders the content as a go template, the default
+	EngineGoTemplate = "gotemplate"
+	// EngineJsonnet evaluates the content as jsonnet
+	EngineJsonnet = "jsonnet"
+	// EngineCUE evaluates the content as cue
+	EngineCUE = "cue"
+)
+
+// Engines is a list of the supported rendering engines
+var Engines = []string{EngineGoTemplate, EngineJsonnet, EngineCUE}
+
+// ReservedParameters are names which cannot be used as template parameters as they are
+// used by the render context i.e. {{ .Resource.Name }}
+var ReservedParameters = []string{"Cloud", "Cluster", "Namespace", "Params", "Resource", "Template"}
+
 // TemplateSpec defines the specification for a template
 type TemplateSpec struct {
 	// Content is the tempate content
@@ -208,6 +463,162 @@ type TemplateSpec struct {
 	// Secrets is a mapping for outputs to kube secrets
 	// +optional
 	Secrets []Secret `json:"secrets,omitempty" protobuf:"bytes,7,ops,name=secrets,casttype=Secret"`
+	// DeleteOn is the default deletion policy for resources using the template
+	// +optional
+	DeleteOn *string `json:"deleteOn,omitempty" protobuf:"bytes,8,opt,name=deleteOn"`
+	// Engine is the rendering engine for the content i.e. gotemplate, jsonnet or cue
+	// +optional
+	Engine string `json:"engine,omitempty" protobuf:"bytes,9,opt,name=engine"`
+	// Extends is the name of a base template this template inherits the content, parameters and
+	// secrets from; the parameters, secrets and define blocks of this template take precedence
+	// +optional
+	Extends string `json:"extends,omitempty" protobuf:"bytes,10,opt,name=extends"`
+	// Fragments are the names of the template fragments whose define blocks are made available to
+	// the content i.e. {{ include "tags" . }}
+	// +optional
+	Fragments []string `json:"fragments,omitempty" protobuf:"bytes,11,rep,name=fragments"`
+	// ContentFrom sources the content from a configmap or the templates directory of the controller
+	// rather than inline
+	// +optional
+	ContentFrom *ContentSource `json:"contentFrom,omitempty" protobuf:"bytes,12,opt,name=contentFrom"`
+	// Deprecated indicates the template should no longer be used for new resources
+	// +optional
+	Deprecated bool `json:"deprecated,omitempty" protobuf:"varint,13,opt,name=deprecated"`
+	// DeprecationMessage is a human readable message explaining the deprecation
+	// +optional
+	DeprecationMessage string `json:"deprecationMessage,omitempty" protobuf:"bytes,14,opt,name=deprecationMessage"`
+	// Successor is the template replacing a deprecated template and how to migrate resources to it
+	// +optional
+	Successor *TemplateSuccessor `json:"successor,omitempty" protobuf:"bytes,15,opt,name=successor"`
+	// Tests are test cases rendering the template with fixed parameters and discovery values
+	// +optional
+	Tests []TemplateTest `json:"tests,omitempty" protobuf:"bytes,16,rep,name=tests"`
+	// Timeout is the duration an operation on the stack may take, defaulting to the controller stack timeout
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,17,opt,name=timeout"`
+}
+
+// TemplateTest is a test case for a template, the template is rendered without access to the cloud
+type TemplateTest struct {
+	// Name is the name of the test case
+	// +required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Parameters are the parameters of the resource
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty" protobuf:"bytes,2,rep,name=parameters"`
+	// Discovery are the values returned by the discovery functions i.e. vpc, subnets, region
+	// +optional
+	Discovery *DiscoveryStubs `json:"discovery,omitempty" protobuf:"bytes,3,opt,name=discovery"`
+	// Expected is the golden rendered template
+	// +optional
+	Expected string `json:"expected,omitempty" protobuf:"bytes,4,opt,name=expected"`
+	// Assertions are jsonpath assertions against the rendered template
+	// +optional
+	Assertions []TemplateAssertion `json:"assertions,omitempty" protobuf:"bytes,5,rep,name=assertions"`
+	// ExpectError is text the render error must contain, the render is expected to fail
+	// +optional
+	ExpectError string `json:"expectError,omitempty" protobuf:"bytes,6,opt,name=expectError"`
+}
+
+// TemplateAssertion is an assertion on a value in the rendered template
+type TemplateAssertion struct {
+	// Path is a jsonpath into the rendered template i.e. {.Resources.Bucket.Type}
+	// +required
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// Value is the expected value, when not set the path must exist
+	// +optional
+	Value *string `json:"value,omitempty" protobuf:"bytes,2,opt,name=value"`
+}
+
+// DiscoveryStubs are the values returned by the discovery functions in a test; a lookup which has
+// not been stubbed fails the render
+type DiscoveryStubs struct {
+	// AccountID is returned by accountID
+	// +optional
+	AccountID string `json:"accountID,omitempty" protobuf:"bytes,1,opt,name=accountID"`
+	// AvailabilityZones is returned by availabilityZones
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty" protobuf:"bytes,2,rep,name=availabilityZones"`
+	// Region is returned by region
+	// +optional
+	Region string `json:"region,omitempty" protobuf:"bytes,3,opt,name=region"`
+	// Subnets are returned by subnets, subnetsWithTag, publicSubnets and privateSubnets
+	// +optional
+	Subnets []StubNetwork `json:"subnets,omitempty" protobuf:"bytes,4,rep,name=subnets"`
+	// VPC is returned by vpc and vpcid
+	// +optional
+	VPC *StubNetwork `json:"vpc,omitempty" protobuf:"bytes,5,opt,name=vpc"`
+	// Lookups are returned by the lookups taking arguments, keyed on the function and its arguments
+	// i.e. hostedZone/example.com, kmsKeyArn/alias/key or latestAMI/amzn2-ami-hvm-*/amazon; the hosted
+	// zone is stubbed by its id
+	// +optional
+	Lookups map[string]string `json:"lookups,omitempty" protobuf:"bytes,6,rep,name=lookups"`
+}
+
+// StubNetwork is a stubbed vpc or subnet
+type StubNetwork struct {
+	// ID is the id of the network
+	// +optional
+	ID string `json:"id,omitempty" protobuf:"bytes,1,opt,name=id"`
+	// Name is the name of the network
+	// +optional
+	Name string `json:"name,omitempty" protobuf:"bytes,2,opt,name=name"`
+	// CIDR is the cidr of the network
+	// +optional
+	CIDR string `json:"cidr,omitempty" protobuf:"bytes,3,opt,name=cidr"`
+	// AvailabilityZone is the zone of a subnet
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty" protobuf:"bytes,4,opt,name=availabilityZone"`
+	// Tags are the tags on the network
+	// +optional
+	Tags map[string]string `json:"tags,omitempty" protobuf:"bytes,5,rep,name=tags"`
+}
+
+// TemplateSuccessor is the template replacing a deprecated template; parameters of the resource
+// which are not mapped are carried over when the successor declares them
+type TemplateSuccessor struct {
+	// Name is the name of the successor template
+	// +required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Parameters maps the parameters of the deprecated template to those of the successor
+	// +optional
+	Parameters []ParameterMapping `json:"parameters,omitempty" protobuf:"bytes,2,rep,name=parameters"`
+}
+
+// ParameterMapping maps a parameter onto a parameter of the successor template
+type ParameterMapping struct {
+	// From is the name of the parameter on the deprecated template
+	// +optional
+	From string `json:"from,omitempty" protobuf:"bytes,1,opt,name=from"`
+	// To is the name of the parameter on the successor template
+	// +required
+	To string `json:"to" protobuf:"bytes,2,opt,name=to"`
+	// Value is a fixed value for the parameter on the successor template, used in place of from
+	// +optional
+	Value *string `json:"value,omitempty" protobuf:"bytes,3,opt,name=value"`
+}
+
+// ContentSource is the source of the template content, only one source may be set
+type ContentSource struct {
+	// ConfigMapKeyRef is a key in a configmap, the configmap must carry the template content label
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyReference `json:"configMapKeyRef,omitempty" protobuf:"bytes,1,opt,name=configMapKeyRef"`
+	// File is a path relative to the templates directory of the controller
+	// +optional
+	File string `json:"file,omitempty" protobuf:"bytes,2,opt,name=file"`
+}
+
+// ConfigMapKeyReference is a reference to a key in a configmap
+type ConfigMapKeyReference struct {
+	// Name is the name of the configmap
+	// +required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Namespace is the namespace of the configmap
+	// +required
+	Namespace string `json:"namespace" protobuf:"bytes,2,opt,name=namespace"`
+	// Key is the key in the configmap holding the content
+	// +required
+	Key string `json:"key" protobuf:"bytes,3,opt,name=key"`
 }
 
 // TemplateSpecStatus is the status information related to a template
@@ -221,4 +632,230 @@ type TemplateSpecStatus struct {
 	// A brief CamelCase message indicating details about why the template is in this state.
 	// +optional
 	Reason string `json:"reason,omitempty" protobuf:"bytes,3,opt,name=reason"`
+	// Checksum is the sha256 of the template content as last validated
+	// +optional
+	Checksum string `json:"checksum,omitempty" protobuf:"bytes,4,opt,name=checksum"`
+	// BlockingResources are the resources i.e. namespace/name preventing the deletion of the template
+	// +optional
+	BlockingResources []string `json:"blockingResources,omitempty" protobuf:"bytes,5,rep,name=blockingResources"`
+	// Resources is a summary of the resources using the template by condition
+	// +optional
+	Resources TemplateResourceSummary `json:"resources,omitempty" protobuf:"bytes,6,opt,name=resources"`
+	// Findings are the problems found analysing the parameters and outputs of the template
+	// +optional
+	Findings []TemplateFinding `json:"findings,omitempty" protobuf:"bytes,7,rep,name=findings"`
+	// Tests are the results of the test cases of the template
+	// +optional
+	Tests []TemplateTestResult `json:"tests,omitempty" protobuf:"bytes,8,rep,name=tests"`
+}
+
+// TemplateTestResult is the result of a test case of the template
+type TemplateTestResult struct {
+	// Name is the name of the test case
+	// +required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Passed indicates the test case passed
+	// +required
+	Passed bool `json:"passed" protobuf:"varint,2,opt,name=passed"`
+	// Message describes why the test case failed
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+}
+
+const (
+	// FindingSeverityError indicates the template will fail to render or map its outputs
+	FindingSeverityError = "Error"
+	// FindingSeverityWarning indicates a likely mistake in the template
+	FindingSeverityWarning = "Warning"
+)
+
+const (
+	// FindingUndeclaredParameter indicates the content references a parameter which is not declared
+	FindingUndeclaredParameter = "UndeclaredParameter"
+	// FindingUnusedParameter indicates a declared parameter is never referenced by the content
+	FindingUnusedParameter = "UnusedParameter"
+	// FindingUnknownOutput indicates a secret maps an output the rendered template does not define
+	FindingUnknownOutput = "UnknownOutput"
+	// FindingOutputsUnchecked indicates the template could not be rendered to check the outputs
+	FindingOutputsUnchecked = "OutputsUnchecked"
+)
+
+// TemplateFinding is a problem found analysing the template
+type TemplateFinding struct {
+	// Severity is the severity of the finding i.e. Error or Warning
+	// +required
+	Severity string `json:"severity" protobuf:"bytes,1,opt,name=severity"`
+	// Type is the type of finding i.e. UndeclaredParameter
+	// +required
+	Type string `json:"type" protobuf:"bytes,2,opt,name=type"`
+	// Field is the path of the field in the template the finding relates to
+	// +optional
+	Field string `json:"field,omitempty" protobuf:"bytes,3,opt,name=field"`
+	// Message is a human readable description of the finding
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,4,opt,name=message"`
+}
+
+// TemplateResourceSummary is a count of the resources using the template by condition
+type TemplateResourceSummary struct {
+	// Total is the number of resources using the template
+	// +optional
+	Total int32 `json:"total" protobuf:"varint,1,opt,name=total"`
+	// Ready is the number of resources whose stack is complete
+	// +optional
+	Ready int32 `json:"ready" protobuf:"varint,2,opt,name=ready"`
+	// Failed is the number of resources whose stack has failed or rolled back
+	// +optional
+	Failed int32 `json:"failed" protobuf:"varint,3,opt,name=failed"`
+	// Progressing is the number of resources whose stack is in progress or has no status yet
+	// +optional
+	Progressing int32 `json:"progressing" protobuf:"varint,4,opt,name=progressing"`
+}
+
+const (
+	// OperatorEquals requires the value equals the first of the values
+	OperatorEquals = "Equals"
+	// OperatorNotEquals requires the value does not equal the first of the values
+	OperatorNotEquals = "NotEquals"
+	// OperatorIn requires the value is one of the values
+	OperatorIn = "In"
+	// OperatorNotIn requires the value is not one of the values
+	OperatorNotIn = "NotIn"
+	// OperatorExists requires the path exists in the resource
+	OperatorExists = "Exists"
+	// OperatorNotExists requires the path does not exist in the resource
+	OperatorNotExists = "NotExists"
+	// OperatorMatches requires the value matches the regexp in the first of the values
+	OperatorMatches = "Matches"
+	// OperatorLessThan requires the numeric value is less than the first of the values
+	OperatorLessThan = "LessThan"
+	// OperatorGreaterThan requires the numeric value is greater than the first of the values
+	OperatorGreaterThan = "GreaterThan"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudPolicy is a set of guardrails applied to the rendered cloud templates
+type CloudPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec is the specification of the policy
+	Spec CloudPolicySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudPolicyList is a list of CloudPolicy items
+type CloudPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Items is a list of CloudPolicy
+	Items []CloudPolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// CloudPolicySpec defines the specification for a cloud policy
+type CloudPolicySpec struct {
+	// AllowedTypes is a list of resource types permitted i.e. AWS::S3::*, when empty all types are permitted
+	// +optional
+	AllowedTypes []string `json:"allowedTypes,omitempty" protobuf:"bytes,1,rep,name=allowedTypes"`
+	// DeniedTypes is a list of resource types which are not permitted i.e. AWS::IAM::User
+	// +optional
+	DeniedTypes []string `json:"deniedTypes,omitempty" protobuf:"bytes,2,rep,name=deniedTypes"`
+	// Constraints is a collection of property constraints on the resources
+	// +optional
+	Constraints []PolicyConstraint `json:"constraints,omitempty" protobuf:"bytes,3,rep,name=constraints"`
+}
+
+// PolicyConstraint is a constraint on the property of a resource
+type PolicyConstraint struct {
+	// Name is the name of the constraint
+	// +required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Message is an optional message returned on a violation
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+	// Operator is the comparison performed against the values
+	// +required
+	Operator string `json:"operator" protobuf:"bytes,3,opt,name=operator"`
+	// Path is a JSONPath into the resource i.e. {.Properties.AccessControl}
+	// +required
+	Path string `json:"path" protobuf:"bytes,4,opt,name=path"`
+	// ResourceType is the resource types the constraint applies to, when empty all types
+	// +optional
+	ResourceType string `json:"resourceType,omitempty" protobuf:"bytes,5,opt,name=resourceType"`
+	// Values are the values used by the operator
+	// +optional
+	Values []string `json:"values,omitempty" protobuf:"bytes,6,rep,name=values"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudQuota limits the cloud resources which can be provisioned in a namespace
+type CloudQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec is the specification of the quota
+	Spec CloudQuotaSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	// Status is the current usage of the quota
+	Status CloudQuotaStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudQuotaList is a list of CloudQuota items
+type CloudQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Items is a list of CloudQuota
+	Items []CloudQuota `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// CloudQuotaSpec defines the limits placed on a namespace
+type CloudQuotaSpec struct {
+	// AllowedTemplates is a list of templates usable in the namespace, when empty all templates are permitted
+	// +optional
+	AllowedTemplates []string `json:"allowedTemplates,omitempty" protobuf:"bytes,1,rep,name=allowedTemplates"`
+	// MaxStacks is the maximum number of active stacks, including those pending deletion
+	// +optional
+	MaxStacks *int `json:"maxStacks,omitempty" protobuf:"varint,2,opt,name=maxStacks"`
+	// Templates is a collection of limits on specific templates
+	// +optional
+	Templates []TemplateQuota `json:"templates,omitempty" protobuf:"bytes,3,rep,name=templates"`
+}
+
+// TemplateQuota is the limit on resources built from a template
+type TemplateQuota struct {
+	// Name is the name of the template
+	// +required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// MaxResources is the maximum number of stacks from the template
+	// +required
+	MaxResources int `json:"maxResources" protobuf:"varint,2,opt,name=maxResources"`
+}
+
+// CloudQuotaStatus is the current usage of the quota
+type CloudQuotaStatus struct {
+	// Stacks is the number of active stacks in the namespace, including those pending deletion
+	// +optional
+	Stacks int `json:"stacks" protobuf:"varint,1,opt,name=stacks"`
+	// Templates is the number of stacks per template
+	// +optional
+	Templates []TemplateUsage `json:"templates,omitempty" protobuf:"bytes,2,rep,name=templates"`
+	// LastUpdated is the time the usage was last calculated
+	// +optional
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty" protobuf:"bytes,3,opt,name=lastUpdated"`
+}
+
+// TemplateUsage is the number of stacks built from a template
+type TemplateUsage struct {
+	// Name is the name of the template
+	// +required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Count is the number of stacks
+	// +required
+	Count int `json:"count" protobuf:"varint,2,opt,name=count"`
 }
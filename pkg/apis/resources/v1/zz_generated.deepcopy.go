@@ -25,6 +25,221 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudPolicy) DeepCopyInto(out *CloudPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudPolicy.
+func (in *CloudPolicy) DeepCopy() *CloudPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudPolicyList) DeepCopyInto(out *CloudPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudPolicyList.
+func (in *CloudPolicyList) DeepCopy() *CloudPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudPolicySpec) DeepCopyInto(out *CloudPolicySpec) {
+	*out = *in
+	if in.AllowedTypes != nil {
+		in, out := &in.AllowedTypes, &out.AllowedTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedTypes != nil {
+		in, out := &in.DeniedTypes, &out.DeniedTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = make([]PolicyConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudPolicySpec.
+func (in *CloudPolicySpec) DeepCopy() *CloudPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudQuota) DeepCopyInto(out *CloudQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudQuota.
+func (in *CloudQuota) DeepCopy() *CloudQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudQuotaList) DeepCopyInto(out *CloudQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudQuotaList.
+func (in *CloudQuotaList) DeepCopy() *CloudQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudQuotaSpec) DeepCopyInto(out *CloudQuotaSpec) {
+	*out = *in
+	if in.AllowedTemplates != nil {
+		in, out := &in.AllowedTemplates, &out.AllowedTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxStacks != nil {
+		in, out := &in.MaxStacks, &out.MaxStacks
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int)
+			**out = **in
+		}
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]TemplateQuota, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudQuotaSpec.
+func (in *CloudQuotaSpec) DeepCopy() *CloudQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudQuotaStatus) DeepCopyInto(out *CloudQuotaStatus) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]TemplateUsage, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudQuotaStatus.
+func (in *CloudQuotaStatus) DeepCopy() *CloudQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudResource) DeepCopyInto(out *CloudResource) {
 	*out = *in
@@ -122,6 +337,15 @@ func (in *CloudResourceSpec) DeepCopyInto(out *CloudResourceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -140,6 +364,49 @@ func (in *CloudStatus) DeepCopyInto(out *CloudStatus) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(StackDiff)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(StackOperation)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ReconcileCheckpoint)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Stack != nil {
+		in, out := &in.Stack, &out.Stack
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(StackState)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -202,7 +469,7 @@ func (in *CloudTemplate) DeepCopyInto(out *CloudTemplate) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -226,32 +493,26 @@ func (in *CloudTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CloudTemplateList) DeepCopyInto(out *CloudTemplateList) {
+func (in *CloudTemplateFragment) DeepCopyInto(out *CloudTemplateFragment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CloudTemplate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudTemplateList.
-func (in *CloudTemplateList) DeepCopy() *CloudTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudTemplateFragment.
+func (in *CloudTemplateFragment) DeepCopy() *CloudTemplateFragment {
 	if in == nil {
 		return nil
 	}
-	out := new(CloudTemplateList)
+	out := new(CloudTemplateFragment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CloudTemplateList) DeepCopyObject() runtime.Object {
+func (in *CloudTemplateFragment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	} else {
@@ -260,56 +521,289 @@ func (in *CloudTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Parameter) DeepCopyInto(out *Parameter) {
+func (in *CloudTemplateFragmentList) DeepCopyInto(out *CloudTemplateFragmentList) {
 	*out = *in
-	if in.SecretName != nil {
-		in, out := &in.SecretName, &out.SecretName
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(string)
-			**out = **in
-		}
-	}
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(string)
-			**out = **in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudTemplateFragment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Parameter.
-func (in *Parameter) DeepCopy() *Parameter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudTemplateFragmentList.
+func (in *CloudTemplateFragmentList) DeepCopy() *CloudTemplateFragmentList {
 	if in == nil {
 		return nil
 	}
-	out := new(Parameter)
+	out := new(CloudTemplateFragmentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudTemplateFragmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Secret) DeepCopyInto(out *Secret) {
+func (in *CloudTemplateList) DeepCopyInto(out *CloudTemplateList) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = make([]SecretValue, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Secret.
-func (in *Secret) DeepCopy() *Secret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudTemplateList.
+func (in *CloudTemplateList) DeepCopy() *CloudTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(Secret)
+	out := new(CloudTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentSource) DeepCopyInto(out *ContentSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConfigMapKeyReference)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentSource.
+func (in *ContentSource) DeepCopy() *ContentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveryStubs) DeepCopyInto(out *DiscoveryStubs) {
+	*out = *in
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]StubNetwork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VPC != nil {
+		in, out := &in.VPC, &out.VPC
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(StubNetwork)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Lookups != nil {
+		in, out := &in.Lookups, &out.Lookups
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryStubs.
+func (in *DiscoveryStubs) DeepCopy() *DiscoveryStubs {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveryStubs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Parameter) DeepCopyInto(out *Parameter) {
+	*out = *in
+	if in.SecretName != nil {
+		in, out := &in.SecretName, &out.SecretName
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Parameter.
+func (in *Parameter) DeepCopy() *Parameter {
+	if in == nil {
+		return nil
+	}
+	out := new(Parameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterMapping) DeepCopyInto(out *ParameterMapping) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterMapping.
+func (in *ParameterMapping) DeepCopy() *ParameterMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyConstraint) DeepCopyInto(out *PolicyConstraint) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyConstraint.
+func (in *PolicyConstraint) DeepCopy() *PolicyConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileCheckpoint) DeepCopyInto(out *ReconcileCheckpoint) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileCheckpoint.
+func (in *ReconcileCheckpoint) DeepCopy() *ReconcileCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Secret) DeepCopyInto(out *Secret) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]SecretValue, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Secret.
+func (in *Secret) DeepCopy() *Secret {
+	if in == nil {
+		return nil
+	}
+	out := new(Secret)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -330,6 +824,225 @@ func (in *SecretValue) DeepCopy() *SecretValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackDiff) DeepCopyInto(out *StackDiff) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]TagDiff, len(*in))
+		copy(*out, *in)
+	}
+	in.LastCheckedTime.DeepCopyInto(&out.LastCheckedTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackDiff.
+func (in *StackDiff) DeepCopy() *StackDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(StackDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackOperation) DeepCopyInto(out *StackOperation) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
+	if in.Superseded != nil {
+		in, out := &in.Superseded, &out.Superseded
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(StackSupersede)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackOperation.
+func (in *StackOperation) DeepCopy() *StackOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(StackOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackState) DeepCopyInto(out *StackState) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackState.
+func (in *StackState) DeepCopy() *StackState {
+	if in == nil {
+		return nil
+	}
+	out := new(StackState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackSupersede) DeepCopyInto(out *StackSupersede) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackSupersede.
+func (in *StackSupersede) DeepCopy() *StackSupersede {
+	if in == nil {
+		return nil
+	}
+	out := new(StackSupersede)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StubNetwork) DeepCopyInto(out *StubNetwork) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StubNetwork.
+func (in *StubNetwork) DeepCopy() *StubNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(StubNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagDiff) DeepCopyInto(out *TagDiff) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagDiff.
+func (in *TagDiff) DeepCopy() *TagDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(TagDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateAssertion) DeepCopyInto(out *TemplateAssertion) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateAssertion.
+func (in *TemplateAssertion) DeepCopy() *TemplateAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateFinding) DeepCopyInto(out *TemplateFinding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateFinding.
+func (in *TemplateFinding) DeepCopy() *TemplateFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateFragmentSpec) DeepCopyInto(out *TemplateFragmentSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateFragmentSpec.
+func (in *TemplateFragmentSpec) DeepCopy() *TemplateFragmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateFragmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateQuota) DeepCopyInto(out *TemplateQuota) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateQuota.
+func (in *TemplateQuota) DeepCopy() *TemplateQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateResourceSummary) DeepCopyInto(out *TemplateResourceSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateResourceSummary.
+func (in *TemplateResourceSummary) DeepCopy() *TemplateResourceSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateResourceSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplateSpec) DeepCopyInto(out *TemplateSpec) {
 	*out = *in
@@ -356,6 +1069,54 @@ func (in *TemplateSpec) DeepCopyInto(out *TemplateSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DeleteOn != nil {
+		in, out := &in.DeleteOn, &out.DeleteOn
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.Fragments != nil {
+		in, out := &in.Fragments, &out.Fragments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContentFrom != nil {
+		in, out := &in.ContentFrom, &out.ContentFrom
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ContentSource)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Successor != nil {
+		in, out := &in.Successor, &out.Successor
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TemplateSuccessor)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]TemplateTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(meta_v1.Duration)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -372,6 +1133,22 @@ func (in *TemplateSpec) DeepCopy() *TemplateSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplateSpecStatus) DeepCopyInto(out *TemplateSpecStatus) {
 	*out = *in
+	if in.BlockingResources != nil {
+		in, out := &in.BlockingResources, &out.BlockingResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Resources = in.Resources
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]TemplateFinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]TemplateTestResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -384,3 +1161,97 @@ func (in *TemplateSpecStatus) DeepCopy() *TemplateSpecStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateSuccessor) DeepCopyInto(out *TemplateSuccessor) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ParameterMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSuccessor.
+func (in *TemplateSuccessor) DeepCopy() *TemplateSuccessor {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateSuccessor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateTest) DeepCopyInto(out *TemplateTest) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(DiscoveryStubs)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]TemplateAssertion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateTest.
+func (in *TemplateTest) DeepCopy() *TemplateTest {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateTestResult) DeepCopyInto(out *TemplateTestResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateTestResult.
+func (in *TemplateTestResult) DeepCopy() *TemplateTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateUsage) DeepCopyInto(out *TemplateUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateUsage.
+func (in *TemplateUsage) DeepCopy() *TemplateUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateUsage)
+	in.DeepCopyInto(out)
+	return out
+}
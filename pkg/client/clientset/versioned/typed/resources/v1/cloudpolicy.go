@@ -0,0 +1,144 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	scheme "github.com/gambol99/resources/pkg/client/clientset/versioned/scheme"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CloudPoliciesGetter has a method to return a CloudPolicyInterface.
+// A group's client should implement this interface.
+type CloudPoliciesGetter interface {
+	CloudPolicies() CloudPolicyInterface
+}
+
+// CloudPolicyInterface has methods to work with CloudPolicy resources.
+type CloudPolicyInterface interface {
+	Create(*v1.CloudPolicy) (*v1.CloudPolicy, error)
+	Update(*v1.CloudPolicy) (*v1.CloudPolicy, error)
+	Delete(name string, options *meta_v1.DeleteOptions) error
+	DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error
+	Get(name string, options meta_v1.GetOptions) (*v1.CloudPolicy, error)
+	List(opts meta_v1.ListOptions) (*v1.CloudPolicyList, error)
+	Watch(opts meta_v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.CloudPolicy, err error)
+	CloudPolicyExpansion
+}
+
+// cloudPolicies implements CloudPolicyInterface
+type cloudPolicies struct {
+	client rest.Interface
+}
+
+// newCloudPolicies returns a CloudPolicies
+func newCloudPolicies(c *CloudV1Client) *cloudPolicies {
+	return &cloudPolicies{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the cloudPolicy, and returns the corresponding cloudPolicy object, and an error if there is any.
+func (c *cloudPolicies) Get(name string, options meta_v1.GetOptions) (result *v1.CloudPolicy, err error) {
+	result = &v1.CloudPolicy{}
+	err = c.client.Get().
+		Resource("cloudpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of CloudPolicies that match those selectors.
+func (c *cloudPolicies) List(opts meta_v1.ListOptions) (result *v1.CloudPolicyList, err error) {
+	result = &v1.CloudPolicyList{}
+	err = c.client.Get().
+		Resource("cloudpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested cloudPolicies.
+func (c *cloudPolicies) Watch(opts meta_v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("cloudpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a cloudPolicy and creates it.  Returns the server's representation of the cloudPolicy, and an error, if there is any.
+func (c *cloudPolicies) Create(cloudPolicy *v1.CloudPolicy) (result *v1.CloudPolicy, err error) {
+	result = &v1.CloudPolicy{}
+	err = c.client.Post().
+		Resource("cloudpolicies").
+		Body(cloudPolicy).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a cloudPolicy and updates it. Returns the server's representation of the cloudPolicy, and an error, if there is any.
+func (c *cloudPolicies) Update(cloudPolicy *v1.CloudPolicy) (result *v1.CloudPolicy, err error) {
+	result = &v1.CloudPolicy{}
+	err = c.client.Put().
+		Resource("cloudpolicies").
+		Name(cloudPolicy.Name).
+		Body(cloudPolicy).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the cloudPolicy and deletes it. Returns an error if one occurs.
+func (c *cloudPolicies) Delete(name string, options *meta_v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("cloudpolicies").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *cloudPolicies) DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("cloudpolicies").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched cloudPolicy.
+func (c *cloudPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.CloudPolicy, err error) {
+	result = &v1.CloudPolicy{}
+	err = c.client.Patch(pt).
+		Resource("cloudpolicies").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
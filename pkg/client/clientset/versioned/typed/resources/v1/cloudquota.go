@@ -0,0 +1,171 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	scheme "github.com/gambol99/resources/pkg/client/clientset/versioned/scheme"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CloudQuotasGetter has a method to return a CloudQuotaInterface.
+// A group's client should implement this interface.
+type CloudQuotasGetter interface {
+	CloudQuotas(namespace string) CloudQuotaInterface
+}
+
+// CloudQuotaInterface has methods to work with CloudQuota resources.
+type CloudQuotaInterface interface {
+	Create(*v1.CloudQuota) (*v1.CloudQuota, error)
+	Update(*v1.CloudQuota) (*v1.CloudQuota, error)
+	UpdateStatus(*v1.CloudQuota) (*v1.CloudQuota, error)
+	Delete(name string, options *meta_v1.DeleteOptions) error
+	DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error
+	Get(name string, options meta_v1.GetOptions) (*v1.CloudQuota, error)
+	List(opts meta_v1.ListOptions) (*v1.CloudQuotaList, error)
+	Watch(opts meta_v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.CloudQuota, err error)
+	CloudQuotaExpansion
+}
+
+// cloudQuotas implements CloudQuotaInterface
+type cloudQuotas struct {
+	client rest.Interface
+	ns     string
+}
+
+// newCloudQuotas returns a CloudQuotas
+func newCloudQuotas(c *CloudV1Client, namespace string) *cloudQuotas {
+	return &cloudQuotas{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the cloudQuota, and returns the corresponding cloudQuota object, and an error if there is any.
+func (c *cloudQuotas) Get(name string, options meta_v1.GetOptions) (result *v1.CloudQuota, err error) {
+	result = &v1.CloudQuota{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of CloudQuotas that match those selectors.
+func (c *cloudQuotas) List(opts meta_v1.ListOptions) (result *v1.CloudQuotaList, err error) {
+	result = &v1.CloudQuotaList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested cloudQuotas.
+func (c *cloudQuotas) Watch(opts meta_v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a cloudQuota and creates it.  Returns the server's representation of the cloudQuota, and an error, if there is any.
+func (c *cloudQuotas) Create(cloudQuota *v1.CloudQuota) (result *v1.CloudQuota, err error) {
+	result = &v1.CloudQuota{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		Body(cloudQuota).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a cloudQuota and updates it. Returns the server's representation of the cloudQuota, and an error, if there is any.
+func (c *cloudQuotas) Update(cloudQuota *v1.CloudQuota) (result *v1.CloudQuota, err error) {
+	result = &v1.CloudQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		Name(cloudQuota.Name).
+		Body(cloudQuota).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *cloudQuotas) UpdateStatus(cloudQuota *v1.CloudQuota) (result *v1.CloudQuota, err error) {
+	result = &v1.CloudQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		Name(cloudQuota.Name).
+		SubResource("status").
+		Body(cloudQuota).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the cloudQuota and deletes it. Returns an error if one occurs.
+func (c *cloudQuotas) Delete(name string, options *meta_v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *cloudQuotas) DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched cloudQuota.
+func (c *cloudQuotas) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.CloudQuota, err error) {
+	result = &v1.CloudQuota{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("cloudquotas").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
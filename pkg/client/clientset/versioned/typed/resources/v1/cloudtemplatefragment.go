@@ -0,0 +1,144 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	scheme "github.com/gambol99/resources/pkg/client/clientset/versioned/scheme"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CloudTemplateFragmentsGetter has a method to return a CloudTemplateFragmentInterface.
+// A group's client should implement this interface.
+type CloudTemplateFragmentsGetter interface {
+	CloudTemplateFragments() CloudTemplateFragmentInterface
+}
+
+// CloudTemplateFragmentInterface has methods to work with CloudTemplateFragment resources.
+type CloudTemplateFragmentInterface interface {
+	Create(*v1.CloudTemplateFragment) (*v1.CloudTemplateFragment, error)
+	Update(*v1.CloudTemplateFragment) (*v1.CloudTemplateFragment, error)
+	Delete(name string, options *meta_v1.DeleteOptions) error
+	DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error
+	Get(name string, options meta_v1.GetOptions) (*v1.CloudTemplateFragment, error)
+	List(opts meta_v1.ListOptions) (*v1.CloudTemplateFragmentList, error)
+	Watch(opts meta_v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.CloudTemplateFragment, err error)
+	CloudTemplateFragmentExpansion
+}
+
+// cloudTemplateFragments implements CloudTemplateFragmentInterface
+type cloudTemplateFragments struct {
+	client rest.Interface
+}
+
+// newCloudTemplateFragments returns a CloudTemplateFragments
+func newCloudTemplateFragments(c *CloudV1Client) *cloudTemplateFragments {
+	return &cloudTemplateFragments{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the cloudTemplateFragment, and returns the corresponding cloudTemplateFragment object, and an error if there is any.
+func (c *cloudTemplateFragments) Get(name string, options meta_v1.GetOptions) (result *v1.CloudTemplateFragment, err error) {
+	result = &v1.CloudTemplateFragment{}
+	err = c.client.Get().
+		Resource("cloudtemplatefragments").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of CloudTemplateFragments that match those selectors.
+func (c *cloudTemplateFragments) List(opts meta_v1.ListOptions) (result *v1.CloudTemplateFragmentList, err error) {
+	result = &v1.CloudTemplateFragmentList{}
+	err = c.client.Get().
+		Resource("cloudtemplatefragments").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested cloudTemplateFragments.
+func (c *cloudTemplateFragments) Watch(opts meta_v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("cloudtemplatefragments").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a cloudTemplateFragment and creates it.  Returns the server's representation of the cloudTemplateFragment, and an error, if there is any.
+func (c *cloudTemplateFragments) Create(cloudTemplateFragment *v1.CloudTemplateFragment) (result *v1.CloudTemplateFragment, err error) {
+	result = &v1.CloudTemplateFragment{}
+	err = c.client.Post().
+		Resource("cloudtemplatefragments").
+		Body(cloudTemplateFragment).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a cloudTemplateFragment and updates it. Returns the server's representation of the cloudTemplateFragment, and an error, if there is any.
+func (c *cloudTemplateFragments) Update(cloudTemplateFragment *v1.CloudTemplateFragment) (result *v1.CloudTemplateFragment, err error) {
+	result = &v1.CloudTemplateFragment{}
+	err = c.client.Put().
+		Resource("cloudtemplatefragments").
+		Name(cloudTemplateFragment.Name).
+		Body(cloudTemplateFragment).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the cloudTemplateFragment and deletes it. Returns an error if one occurs.
+func (c *cloudTemplateFragments) Delete(name string, options *meta_v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("cloudtemplatefragments").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *cloudTemplateFragments) DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error {
+	return c.client.Delete().
+		Resource("cloudtemplatefragments").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched cloudTemplateFragment.
+func (c *cloudTemplateFragments) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.CloudTemplateFragment, err error) {
+	result = &v1.CloudTemplateFragment{}
+	err = c.client.Patch(pt).
+		Resource("cloudtemplatefragments").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
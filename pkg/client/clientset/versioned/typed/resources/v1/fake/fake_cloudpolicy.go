@@ -0,0 +1,117 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	resources_v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCloudPolicies implements CloudPolicyInterface
+type FakeCloudPolicies struct {
+	Fake *FakeCloudV1
+}
+
+var cloudpoliciesResource = schema.GroupVersionResource{Group: "cloud.appvia.io", Version: "v1", Resource: "cloudpolicies"}
+
+var cloudpoliciesKind = schema.GroupVersionKind{Group: "cloud.appvia.io", Version: "v1", Kind: "CloudPolicy"}
+
+// Get takes name of the cloudPolicy, and returns the corresponding cloudPolicy object, and an error if there is any.
+func (c *FakeCloudPolicies) Get(name string, options v1.GetOptions) (result *resources_v1.CloudPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(cloudpoliciesResource, name), &resources_v1.CloudPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of CloudPolicies that match those selectors.
+func (c *FakeCloudPolicies) List(opts v1.ListOptions) (result *resources_v1.CloudPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(cloudpoliciesResource, cloudpoliciesKind, opts), &resources_v1.CloudPolicyList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &resources_v1.CloudPolicyList{}
+	for _, item := range obj.(*resources_v1.CloudPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cloudPolicies.
+func (c *FakeCloudPolicies) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(cloudpoliciesResource, opts))
+}
+
+// Create takes the representation of a cloudPolicy and creates it.  Returns the server's representation of the cloudPolicy, and an error, if there is any.
+func (c *FakeCloudPolicies) Create(cloudPolicy *resources_v1.CloudPolicy) (result *resources_v1.CloudPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(cloudpoliciesResource, cloudPolicy), &resources_v1.CloudPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudPolicy), err
+}
+
+// Update takes the representation of a cloudPolicy and updates it. Returns the server's representation of the cloudPolicy, and an error, if there is any.
+func (c *FakeCloudPolicies) Update(cloudPolicy *resources_v1.CloudPolicy) (result *resources_v1.CloudPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(cloudpoliciesResource, cloudPolicy), &resources_v1.CloudPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudPolicy), err
+}
+
+// Delete takes name of the cloudPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeCloudPolicies) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(cloudpoliciesResource, name), &resources_v1.CloudPolicy{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCloudPolicies) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(cloudpoliciesResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &resources_v1.CloudPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cloudPolicy.
+func (c *FakeCloudPolicies) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *resources_v1.CloudPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(cloudpoliciesResource, name, data, subresources...), &resources_v1.CloudPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudPolicy), err
+}
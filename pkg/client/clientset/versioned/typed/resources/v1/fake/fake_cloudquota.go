@@ -0,0 +1,137 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	resources_v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCloudQuotas implements CloudQuotaInterface
+type FakeCloudQuotas struct {
+	Fake *FakeCloudV1
+	ns   string
+}
+
+var cloudquotasResource = schema.GroupVersionResource{Group: "cloud.appvia.io", Version: "v1", Resource: "cloudquotas"}
+
+var cloudquotasKind = schema.GroupVersionKind{Group: "cloud.appvia.io", Version: "v1", Kind: "CloudQuota"}
+
+// Get takes name of the cloudQuota, and returns the corresponding cloudQuota object, and an error if there is any.
+func (c *FakeCloudQuotas) Get(name string, options v1.GetOptions) (result *resources_v1.CloudQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(cloudquotasResource, c.ns, name), &resources_v1.CloudQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudQuota), err
+}
+
+// List takes label and field selectors, and returns the list of CloudQuotas that match those selectors.
+func (c *FakeCloudQuotas) List(opts v1.ListOptions) (result *resources_v1.CloudQuotaList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(cloudquotasResource, cloudquotasKind, c.ns, opts), &resources_v1.CloudQuotaList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &resources_v1.CloudQuotaList{}
+	for _, item := range obj.(*resources_v1.CloudQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cloudQuotas.
+func (c *FakeCloudQuotas) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(cloudquotasResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cloudQuota and creates it.  Returns the server's representation of the cloudQuota, and an error, if there is any.
+func (c *FakeCloudQuotas) Create(cloudQuota *resources_v1.CloudQuota) (result *resources_v1.CloudQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(cloudquotasResource, c.ns, cloudQuota), &resources_v1.CloudQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudQuota), err
+}
+
+// Update takes the representation of a cloudQuota and updates it. Returns the server's representation of the cloudQuota, and an error, if there is any.
+func (c *FakeCloudQuotas) Update(cloudQuota *resources_v1.CloudQuota) (result *resources_v1.CloudQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(cloudquotasResource, c.ns, cloudQuota), &resources_v1.CloudQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudQuota), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCloudQuotas) UpdateStatus(cloudQuota *resources_v1.CloudQuota) (*resources_v1.CloudQuota, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(cloudquotasResource, "status", c.ns, cloudQuota), &resources_v1.CloudQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudQuota), err
+}
+
+// Delete takes name of the cloudQuota and deletes it. Returns an error if one occurs.
+func (c *FakeCloudQuotas) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(cloudquotasResource, c.ns, name), &resources_v1.CloudQuota{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCloudQuotas) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(cloudquotasResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &resources_v1.CloudQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cloudQuota.
+func (c *FakeCloudQuotas) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *resources_v1.CloudQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(cloudquotasResource, c.ns, name, data, subresources...), &resources_v1.CloudQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudQuota), err
+}
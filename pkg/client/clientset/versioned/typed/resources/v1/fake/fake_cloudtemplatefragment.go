@@ -0,0 +1,117 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	resources_v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCloudTemplateFragments implements CloudTemplateFragmentInterface
+type FakeCloudTemplateFragments struct {
+	Fake *FakeCloudV1
+}
+
+var cloudtemplatefragmentsResource = schema.GroupVersionResource{Group: "cloud.appvia.io", Version: "v1", Resource: "cloudtemplatefragments"}
+
+var cloudtemplatefragmentsKind = schema.GroupVersionKind{Group: "cloud.appvia.io", Version: "v1", Kind: "CloudTemplateFragment"}
+
+// Get takes name of the cloudTemplateFragment, and returns the corresponding cloudTemplateFragment object, and an error if there is any.
+func (c *FakeCloudTemplateFragments) Get(name string, options v1.GetOptions) (result *resources_v1.CloudTemplateFragment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(cloudtemplatefragmentsResource, name), &resources_v1.CloudTemplateFragment{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudTemplateFragment), err
+}
+
+// List takes label and field selectors, and returns the list of CloudTemplateFragments that match those selectors.
+func (c *FakeCloudTemplateFragments) List(opts v1.ListOptions) (result *resources_v1.CloudTemplateFragmentList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(cloudtemplatefragmentsResource, cloudtemplatefragmentsKind, opts), &resources_v1.CloudTemplateFragmentList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &resources_v1.CloudTemplateFragmentList{}
+	for _, item := range obj.(*resources_v1.CloudTemplateFragmentList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cloudTemplateFragments.
+func (c *FakeCloudTemplateFragments) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(cloudtemplatefragmentsResource, opts))
+}
+
+// Create takes the representation of a cloudTemplateFragment and creates it.  Returns the server's representation of the cloudTemplateFragment, and an error, if there is any.
+func (c *FakeCloudTemplateFragments) Create(cloudTemplateFragment *resources_v1.CloudTemplateFragment) (result *resources_v1.CloudTemplateFragment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(cloudtemplatefragmentsResource, cloudTemplateFragment), &resources_v1.CloudTemplateFragment{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudTemplateFragment), err
+}
+
+// Update takes the representation of a cloudTemplateFragment and updates it. Returns the server's representation of the cloudTemplateFragment, and an error, if there is any.
+func (c *FakeCloudTemplateFragments) Update(cloudTemplateFragment *resources_v1.CloudTemplateFragment) (result *resources_v1.CloudTemplateFragment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(cloudtemplatefragmentsResource, cloudTemplateFragment), &resources_v1.CloudTemplateFragment{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudTemplateFragment), err
+}
+
+// Delete takes name of the cloudTemplateFragment and deletes it. Returns an error if one occurs.
+func (c *FakeCloudTemplateFragments) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(cloudtemplatefragmentsResource, name), &resources_v1.CloudTemplateFragment{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCloudTemplateFragments) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(cloudtemplatefragmentsResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &resources_v1.CloudTemplateFragmentList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cloudTemplateFragment.
+func (c *FakeCloudTemplateFragments) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *resources_v1.CloudTemplateFragment, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(cloudtemplatefragmentsResource, name, data, subresources...), &resources_v1.CloudTemplateFragment{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*resources_v1.CloudTemplateFragment), err
+}
@@ -25,6 +25,14 @@ type FakeCloudV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeCloudV1) CloudPolicies() v1.CloudPolicyInterface {
+	return &FakeCloudPolicies{c}
+}
+
+func (c *FakeCloudV1) CloudQuotas(namespace string) v1.CloudQuotaInterface {
+	return &FakeCloudQuotas{c, namespace}
+}
+
 func (c *FakeCloudV1) CloudResources(namespace string) v1.CloudResourceInterface {
 	return &FakeCloudResources{c, namespace}
 }
@@ -37,6 +45,10 @@ func (c *FakeCloudV1) CloudTemplates() v1.CloudTemplateInterface {
 	return &FakeCloudTemplates{c}
 }
 
+func (c *FakeCloudV1) CloudTemplateFragments() v1.CloudTemplateFragmentInterface {
+	return &FakeCloudTemplateFragments{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCloudV1) RESTClient() rest.Interface {
@@ -15,8 +15,14 @@ limitations under the License.
 */
 package v1
 
+type CloudPolicyExpansion interface{}
+
+type CloudQuotaExpansion interface{}
+
 type CloudResourceExpansion interface{}
 
 type CloudStatusExpansion interface{}
 
 type CloudTemplateExpansion interface{}
+
+type CloudTemplateFragmentExpansion interface{}
@@ -24,9 +24,12 @@ import (
 
 type CloudV1Interface interface {
 	RESTClient() rest.Interface
+	CloudPoliciesGetter
+	CloudQuotasGetter
 	CloudResourcesGetter
 	CloudStatusesGetter
 	CloudTemplatesGetter
+	CloudTemplateFragmentsGetter
 }
 
 // CloudV1Client is used to interact with features provided by the cloud.appvia.io group.
@@ -34,6 +37,14 @@ type CloudV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *CloudV1Client) CloudPolicies() CloudPolicyInterface {
+	return newCloudPolicies(c)
+}
+
+func (c *CloudV1Client) CloudQuotas(namespace string) CloudQuotaInterface {
+	return newCloudQuotas(c, namespace)
+}
+
 func (c *CloudV1Client) CloudResources(namespace string) CloudResourceInterface {
 	return newCloudResources(c, namespace)
 }
@@ -46,6 +57,10 @@ func (c *CloudV1Client) CloudTemplates() CloudTemplateInterface {
 	return newCloudTemplates(c)
 }
 
+func (c *CloudV1Client) CloudTemplateFragments() CloudTemplateFragmentInterface {
+	return newCloudTemplateFragments(c)
+}
+
 // NewForConfig creates a new CloudV1Client for the given config.
 func NewForConfig(c *rest.Config) (*CloudV1Client, error) {
 	config := *c
@@ -53,12 +53,18 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=cloud.appvia.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("cloudpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloud().V1().CloudPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("cloudquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloud().V1().CloudQuotas().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cloudresources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloud().V1().CloudResources().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cloudstatuses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloud().V1().CloudStatuses().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cloudtemplates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloud().V1().CloudTemplates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("cloudtemplatefragments"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cloud().V1().CloudTemplateFragments().Informer()}, nil
 
 	}
 
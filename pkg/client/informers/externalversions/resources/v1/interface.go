@@ -24,12 +24,18 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// CloudPolicies returns a CloudPolicyInformer.
+	CloudPolicies() CloudPolicyInformer
+	// CloudQuotas returns a CloudQuotaInformer.
+	CloudQuotas() CloudQuotaInformer
 	// CloudResources returns a CloudResourceInformer.
 	CloudResources() CloudResourceInformer
 	// CloudStatuses returns a CloudStatusInformer.
 	CloudStatuses() CloudStatusInformer
 	// CloudTemplates returns a CloudTemplateInformer.
 	CloudTemplates() CloudTemplateInformer
+	// CloudTemplateFragments returns a CloudTemplateFragmentInformer.
+	CloudTemplateFragments() CloudTemplateFragmentInformer
 }
 
 type version struct {
@@ -43,6 +49,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// CloudPolicies returns a CloudPolicyInformer.
+func (v *version) CloudPolicies() CloudPolicyInformer {
+	return &cloudPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// CloudQuotas returns a CloudQuotaInformer.
+func (v *version) CloudQuotas() CloudQuotaInformer {
+	return &cloudQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CloudResources returns a CloudResourceInformer.
 func (v *version) CloudResources() CloudResourceInformer {
 	return &cloudResourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -57,3 +73,8 @@ func (v *version) CloudStatuses() CloudStatusInformer {
 func (v *version) CloudTemplates() CloudTemplateInformer {
 	return &cloudTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// CloudTemplateFragments returns a CloudTemplateFragmentInformer.
+func (v *version) CloudTemplateFragments() CloudTemplateFragmentInformer {
+	return &cloudTemplateFragmentInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
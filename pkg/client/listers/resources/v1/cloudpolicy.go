@@ -0,0 +1,65 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by lister-gen
+
+package v1
+
+import (
+	v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CloudPolicyLister helps list CloudPolicies.
+type CloudPolicyLister interface {
+	// List lists all CloudPolicies in the indexer.
+	List(selector labels.Selector) (ret []*v1.CloudPolicy, err error)
+	// Get retrieves the CloudPolicy from the index for a given name.
+	Get(name string) (*v1.CloudPolicy, error)
+	CloudPolicyListerExpansion
+}
+
+// cloudPolicyLister implements the CloudPolicyLister interface.
+type cloudPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewCloudPolicyLister returns a new CloudPolicyLister.
+func NewCloudPolicyLister(indexer cache.Indexer) CloudPolicyLister {
+	return &cloudPolicyLister{indexer: indexer}
+}
+
+// List lists all CloudPolicies in the indexer.
+func (s *cloudPolicyLister) List(selector labels.Selector) (ret []*v1.CloudPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.CloudPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the CloudPolicy from the index for a given name.
+func (s *cloudPolicyLister) Get(name string) (*v1.CloudPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("cloudpolicy"), name)
+	}
+	return obj.(*v1.CloudPolicy), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by lister-gen
+
+package v1
+
+import (
+	v1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CloudQuotaLister helps list CloudQuotas.
+type CloudQuotaLister interface {
+	// List lists all CloudQuotas in the indexer.
+	List(selector labels.Selector) (ret []*v1.CloudQuota, err error)
+	// CloudQuotas returns an object that can list and get CloudQuotas.
+	CloudQuotas(namespace string) CloudQuotaNamespaceLister
+	CloudQuotaListerExpansion
+}
+
+// cloudQuotaLister implements the CloudQuotaLister interface.
+type cloudQuotaLister struct {
+	indexer cache.Indexer
+}
+
+// NewCloudQuotaLister returns a new CloudQuotaLister.
+func NewCloudQuotaLister(indexer cache.Indexer) CloudQuotaLister {
+	return &cloudQuotaLister{indexer: indexer}
+}
+
+// List lists all CloudQuotas in the indexer.
+func (s *cloudQuotaLister) List(selector labels.Selector) (ret []*v1.CloudQuota, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.CloudQuota))
+	})
+	return ret, err
+}
+
+// CloudQuotas returns an object that can list and get CloudQuotas.
+func (s *cloudQuotaLister) CloudQuotas(namespace string) CloudQuotaNamespaceLister {
+	return cloudQuotaNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// CloudQuotaNamespaceLister helps list and get CloudQuotas.
+type CloudQuotaNamespaceLister interface {
+	// List lists all CloudQuotas in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.CloudQuota, err error)
+	// Get retrieves the CloudQuota from the indexer for a given namespace and name.
+	Get(name string) (*v1.CloudQuota, error)
+	CloudQuotaNamespaceListerExpansion
+}
+
+// cloudQuotaNamespaceLister implements the CloudQuotaNamespaceLister
+// interface.
+type cloudQuotaNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all CloudQuotas in the indexer for a given namespace.
+func (s cloudQuotaNamespaceLister) List(selector labels.Selector) (ret []*v1.CloudQuota, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.CloudQuota))
+	})
+	return ret, err
+}
+
+// Get retrieves the CloudQuota from the indexer for a given namespace and name.
+func (s cloudQuotaNamespaceLister) Get(name string) (*v1.CloudQuota, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("cloudquota"), name)
+	}
+	return obj.(*v1.CloudQuota), nil
+}
@@ -18,6 +18,18 @@ limitations under the License.
 
 package v1
 
+// CloudPolicyListerExpansion allows custom methods to be added to
+// CloudPolicyLister.
+type CloudPolicyListerExpansion interface{}
+
+// CloudQuotaListerExpansion allows custom methods to be added to
+// CloudQuotaLister.
+type CloudQuotaListerExpansion interface{}
+
+// CloudQuotaNamespaceListerExpansion allows custom methods to be added to
+// CloudQuotaNamespaceLister.
+type CloudQuotaNamespaceListerExpansion interface{}
+
 // CloudResourceListerExpansion allows custom methods to be added to
 // CloudResourceLister.
 type CloudResourceListerExpansion interface{}
@@ -37,3 +49,7 @@ type CloudStatusNamespaceListerExpansion interface{}
 // CloudTemplateListerExpansion allows custom methods to be added to
 // CloudTemplateLister.
 type CloudTemplateListerExpansion interface{}
+
+// CloudTemplateFragmentListerExpansion allows custom methods to be added to
+// CloudTemplateFragmentLister.
+type CloudTemplateFragmentListerExpansion interface{}
@@ -0,0 +1,225 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// analyzePlaceholder is the value of the parameters when rendering the template for analysis
+	analyzePlaceholder = "placeholder"
+)
+
+// Analyze finds the parameters referenced by the content and the outputs of the template when
+// rendered with placeholder values; the references are only found for go templates
+func (p *provider) Analyze(ctx context.Context, template *apiv1.CloudTemplate, partials []string) (*models.TemplateAnalysis, error) {
+	analysis := &models.TemplateAnalysis{}
+
+	if template.Spec.GetEngine() == apiv1.EngineGoTemplate {
+		references, err := p.newTemplater().WithPartials(partials).References(template.Spec.Content)
+		if err != nil {
+			return nil, err
+		}
+		analysis.References = references
+	}
+
+	// @step: render with placeholders for the declared and referenced parameters
+	params := make(map[string]string, 0)
+	for _, x := range template.Spec.Parameters {
+		params[x.Name] = analyzePlaceholder
+	}
+	for _, x := range analysis.References {
+		params[x] = analyzePlaceholder
+	}
+	rendered, err := p.Render(ctx, &models.CreateOptions{
+		Context:  params,
+		Partials: partials,
+		Resource: &apiv1.CloudResource{
+			ObjectMeta: metav1.ObjectMeta{Name: analyzePlaceholder, Namespace: analyzePlaceholder},
+		},
+		Template: template,
+	})
+	if err != nil {
+		analysis.RenderError = err
+
+		return analysis, nil
+	}
+	if analysis.Outputs, err = getTemplateOutputs(rendered); err != nil {
+		analysis.RenderError = err
+	}
+
+	return analysis, nil
+}
+
+// References returns the parameters referenced by the content and partials, i.e. {{ .param }},
+// {{ .Params.param }}, {{ $.param }} or {{ index .Params "param" }}; fields within a range or with
+// block are relative to the element and are not parameters
+func (t *Templater) References(content string) ([]string, error) {
+	tm := template.New("main")
+	if err := t.parse(tm, content); err != nil {
+		return nil, fmt.Errorf("unable to parse the template: %s", err)
+	}
+
+	found := make(map[string]bool, 0)
+	for _, x := range tm.Templates() {
+		if x.Tree == nil || x.Tree.Root == nil {
+			continue
+		}
+		collectReferences(x.Tree.Root, false, found)
+	}
+
+	list := make([]string, 0, len(found))
+	for k := range found {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+
+	return list, nil
+}
+
+// collectReferences walks the node recording the parameters referenced; scoped indicates the dot
+// no longer refers to the render data
+func collectReferences(node parse.Node, scoped bool, found map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, x := range n.Nodes {
+			collectReferences(x, scoped, found)
+		}
+	case *parse.ActionNode:
+		collectReferences(n.Pipe, scoped, found)
+	case *parse.IfNode:
+		collectReferences(n.Pipe, scoped, found)
+		collectReferences(n.List, scoped, found)
+		collectReferences(n.ElseList, scoped, found)
+	case *parse.RangeNode:
+		collectReferences(n.Pipe, scoped, found)
+		collectReferences(n.List, true, found)
+		collectReferences(n.ElseList, scoped, found)
+	case *parse.WithNode:
+		collectReferences(n.Pipe, scoped, found)
+		collectReferences(n.List, true, found)
+		collectReferences(n.ElseList, scoped, found)
+	case *parse.TemplateNode:
+		collectReferences(n.Pipe, scoped, found)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, x := range n.Cmds {
+			collectReferences(x, scoped, found)
+		}
+	case *parse.CommandNode:
+		if name, ok := getIndexReference(n, scoped); ok {
+			found[name] = true
+		}
+		for _, x := range n.Args {
+			collectReferences(x, scoped, found)
+		}
+	case *parse.ChainNode:
+		collectReferences(n.Node, scoped, found)
+	case *parse.FieldNode:
+		if !scoped {
+			addReference(n.Ident, found)
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			addReference(n.Ident[1:], found)
+		}
+	}
+}
+
+// addReference records the parameter referenced by the field i.e. param or Params.param
+func addReference(idents []string, found map[string]bool) {
+	if len(idents) <= 0 {
+		return
+	}
+	if idents[0] == models.RenderKeyParams {
+		if len(idents) > 1 {
+			found[idents[1]] = true
+		}
+		return
+	}
+	for _, x := range apiv1.ReservedParameters {
+		if x == idents[0] {
+			return
+		}
+	}
+	found[idents[0]] = true
+}
+
+// getIndexReference returns the parameter of an index of the parameters i.e. index .Params "param"
+func getIndexReference(n *parse.CommandNode, scoped bool) (string, bool) {
+	if len(n.Args) < 3 {
+		return "", false
+	}
+	if ident, ok := n.Args[0].(*parse.IdentifierNode); !ok || ident.Ident != "index" {
+		return "", false
+	}
+	key, ok := n.Args[2].(*parse.StringNode)
+	if !ok {
+		return "", false
+	}
+	switch a := n.Args[1].(type) {
+	case *parse.FieldNode:
+		if !scoped && len(a.Ident) == 1 && a.Ident[0] == models.RenderKeyParams {
+			return key.Text, true
+		}
+	case *parse.VariableNode:
+		if len(a.Ident) == 2 && a.Ident[0] == "$" && a.Ident[1] == models.RenderKeyParams {
+			return key.Text, true
+		}
+	}
+
+	return "", false
+}
+
+// getTemplateOutputs returns the keys of the outputs section of a rendered template
+func getTemplateOutputs(content string) ([]string, error) {
+	encoded, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the rendered template: %s", err)
+	}
+	document := struct {
+		Outputs map[string]interface{} `json:"Outputs"`
+	}{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, fmt.Errorf("unable to decode the rendered template: %s", err)
+	}
+
+	list := make([]string, 0, len(document.Outputs))
+	for k := range document.Outputs {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+
+	return list, nil
+}
@@ -31,6 +31,12 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/gambol99/resources/pkg/models"
@@ -47,6 +53,14 @@ type provider struct {
 	compute ec2iface.EC2API
 	// the configuration for the provider
 	config *models.ProviderConfig
+	// the route53 client
+	dns route53iface.Route53API
+	// the sts client
+	identity stsiface.STSAPI
+	// the kms client
+	keys kmsiface.KMSAPI
+	// lookups is an optional cache of template lookups across renders
+	lookups *LookupCache
 }
 
 const (
@@ -79,12 +93,30 @@ func New(config *models.ProviderConfig) (models.CloudProvider, error) {
 		return nil, err
 	}
 
-	return &provider{
+	p := &provider{
 		accounts: iam.New(sess),
 		client:   cloudformation.New(sess),
 		compute:  ec2.New(sess),
 		config:   config,
-	}, nil
+		dns:      route53.New(sess),
+		identity: sts.New(sess),
+		keys:     kms.New(sess),
+	}
+	if config.LookupTTL > 0 {
+		p.lookups = NewLookupCache(config.LookupTTL)
+	}
+
+	return p, nil
+}
+
+// templaterClients returns the clients used by the template lookups
+func (p *provider) templaterClients() TemplaterClients {
+	return TemplaterClients{
+		Compute:  p.compute,
+		DNS:      p.dns,
+		Identity: p.identity,
+		Keys:     p.keys,
+	}
 }
 
 // findRegion attempts to find the region from the metadata service
@@ -0,0 +1,56 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// Cancel is responsible for cancelling the operation in progress on the stack; cloudformation only
+// permits an update to be cancelled, the stack rolling back to the previous template
+func (p *provider) Cancel(ctx context.Context, name string) error {
+	stack, _, err := p.getStack(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// @check the ownership of the stack
+	if !p.isOwned(stack) {
+		return models.ErrUnauthorized
+	}
+	if aws.StringValue(stack.StackStatus) != cloudformation.StackStatusUpdateInProgress {
+		return models.ErrNotCancellable
+	}
+
+	// @step: used to record the cancel time
+	metric := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
+		requestDuration.WithLabelValues("cancel").Observe(v)
+	}))
+	defer metric.ObserveDuration()
+
+	_, err = p.client.CancelUpdateStackWithContext(ctx, &cloudformation.CancelUpdateStackInput{
+		StackName: aws.String(name),
+	})
+
+	return classifyError(err)
+}
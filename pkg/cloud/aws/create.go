@@ -40,16 +40,20 @@ func (p *provider) Create(ctx context.Context, name string, options *models.Crea
 		return errors.New("no name specified for stack")
 	}
 
-	// @step: parse and generate the template
-	generated, err := NewTemplater(p.compute, p.config).Render(ctx, options.Context, template.Spec.Content)
-	if err != nil {
-		return err
+	// @step: parse and generate the template if not already rendered
+	generated := options.Content
+	if generated == "" {
+		content, err := p.Render(ctx, options)
+		if err != nil {
+			return err
+		}
+		generated = content
 	}
 	// @step: attempt to validate the stack before sending it, we don't want to waste time
-	if _, err = p.client.ValidateTemplateWithContext(ctx, &cloudformation.ValidateTemplateInput{
+	if _, err := p.client.ValidateTemplateWithContext(ctx, &cloudformation.ValidateTemplateInput{
 		TemplateBody: aws.String(generated),
 	}); err != nil {
-		return err
+		return classifyTemplateError(err)
 	}
 
 	// @step: check if the resource already exists and if so is in-progress
@@ -70,23 +74,35 @@ func (p *provider) Create(ctx context.Context, name string, options *models.Crea
 
 	if !found {
 		// we are creating a new stack
-		if _, err := p.client.CreateStack(&cloudformation.CreateStackInput{
+		input := &cloudformation.CreateStackInput{
 			Capabilities:    aws.StringSlice([]string{"CAPABILITY_IAM"}),
 			DisableRollback: aws.Bool(isTrue),
 			StackName:       aws.String(name),
 			Tags:            makeStackTags(options.Tags),
 			TemplateBody:    aws.String(generated),
-		}); err != nil {
-			return err
+		}
+		if options.OperationID != "" {
+			input.ClientRequestToken = aws.String(options.OperationID)
+		}
+		if _, err := p.client.CreateStack(input); err != nil {
+			return classifyError(err)
 		}
 	} else {
 		// @step: we are updating a cloudformation stack
-		if _, err := p.client.UpdateStack(&cloudformation.UpdateStackInput{
+		input := &cloudformation.UpdateStackInput{
 			StackName:    aws.String(name),
 			Tags:         makeStackTags(options.Tags),
 			TemplateBody: aws.String(generated),
-		}); err != nil {
-			return err
+		}
+		if options.OperationID != "" {
+			input.ClientRequestToken = aws.String(options.OperationID)
+		}
+		// @check an update which changes nothing starts no operation, the stack is already as requested
+		if _, err := p.client.UpdateStack(input); err != nil {
+			if isNoUpdatesError(err) {
+				return models.ErrNoUpdates
+			}
+			return classifyError(err)
 		}
 	}
 
@@ -0,0 +1,258 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/parser"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// cueFilename is the name used for the content in error messages
+	cueFilename = "template.cue"
+)
+
+// cueListParams are the parameters of the lookups taking a list, passed comma separated in cue
+var cueListParams = map[string]bool{"owners": true, "values": true}
+
+// cueRenderer evaluates the content as cue; the render context is injected into the scope of the
+// content i.e. Params.name, Resource.Name, and the lookups under Cloud i.e. Cloud.subnets. As cue has
+// no function calls the lookups taking arguments are indexed by them in order, a list argument being
+// comma separated i.e. Cloud.hostedZone["example.com"] or Cloud.latestAMI["amzn2-ami-hvm-*"]["amazon"]
+type cueRenderer struct {
+	templater *Templater
+}
+
+// cueReference is a lookup referenced by the content
+type cueReference struct {
+	// name is the name of the lookup
+	name string
+	// arguments are the indexes of the lookup
+	arguments []ast.Expr
+}
+
+// Parse checks the content is valid cue
+func (c *cueRenderer) Parse(content string) error {
+	if _, err := parser.ParseFile(cueFilename, content); err != nil {
+		return fmt.Errorf("unable to parse the template: %s", err)
+	}
+
+	return nil
+}
+
+// Render evaluates the cue and returns the concrete document in the format
+func (c *cueRenderer) Render(ctx context.Context, data *models.RenderContext, content, format string) (string, error) {
+	c.templater.ctx = ctx
+	c.templater.err = nil
+
+	capture := prometheus.NewTimer(templateDuration)
+	defer capture.ObserveDuration()
+
+	file, err := parser.ParseFile(cueFilename, content)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the template: %s", err)
+	}
+
+	values := data.Values()
+	cx := cuecontext.New()
+	scope := cx.Encode(values)
+	if err := scope.Err(); err != nil {
+		return "", err
+	}
+
+	// @step: cue has no function calls, so only the lookups referenced by the content are performed
+	lookups, err := c.lookups(cx, scope, getCUEReferences(file))
+	if err != nil {
+		return "", err
+	}
+	values[models.RenderKeyCloud] = lookups
+
+	scope = cx.Encode(values)
+	if err := scope.Err(); err != nil {
+		return "", err
+	}
+
+	v := cx.BuildFile(file, cue.Scope(scope))
+	if err := v.Err(); err != nil {
+		return "", err
+	}
+	if err := v.Validate(cue.Concrete(true)); err != nil {
+		return "", err
+	}
+	generated, err := v.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	return fromJSON(generated, format)
+}
+
+// lookups performs the lookups referenced by the content, the results of a lookup taking arguments
+// are nested under the arguments; the arguments are evaluated in the scope of the render context
+func (c *cueRenderer) lookups(cx *cue.Context, scope cue.Value, references []cueReference) (map[string]interface{}, error) {
+	funcs := make(map[string]discoveryFunc, 0)
+	for _, x := range c.templater.discoveryFuncs() {
+		funcs[x.name] = x
+	}
+
+	lookups := make(map[string]interface{}, 0)
+	for _, x := range references {
+		// @check an unknown lookup is left to cue to report as an undefined field
+		fn, found := funcs[x.name]
+		if !found {
+			continue
+		}
+
+		if len(fn.params) <= 0 {
+			if _, found := lookups[fn.name]; found {
+				continue
+			}
+			v, err := fn.call(nil)
+			if err != nil {
+				return nil, err
+			}
+			if lookups[fn.name], err = toGeneric(v); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if len(x.arguments) <= 0 || len(x.arguments) > len(fn.params) {
+			return nil, fmt.Errorf("lookup: %s takes the arguments: %s i.e. %s.%s[\"%s\"]",
+				fn.name, strings.Join(fn.params, ","), models.RenderKeyCloud, fn.name, fn.params[0])
+		}
+		keys := []string{fn.name}
+		var args []interface{}
+		for i, arg := range x.arguments {
+			key, err := cx.BuildExpr(arg, cue.Scope(scope)).String()
+			if err != nil {
+				return nil, fmt.Errorf("lookup: %s, argument: %s must be a string or taken from the render context, error: %s", fn.name, fn.params[i], err)
+			}
+			keys = append(keys, key)
+			args = append(args, toCUEArgument(fn.params[i], key))
+		}
+		v, err := fn.call(args)
+		if err != nil {
+			return nil, err
+		}
+		generic, err := toGeneric(v)
+		if err != nil {
+			return nil, err
+		}
+		if err := setCUELookup(lookups, keys, generic); err != nil {
+			return nil, err
+		}
+	}
+
+	return lookups, nil
+}
+
+// getCUEReferences returns the lookups referenced under Cloud by the content along with the indexes
+func getCUEReferences(file *ast.File) []cueReference {
+	var list []cueReference
+
+	seen := make(map[ast.Node]bool, 0)
+	ast.Walk(file, func(node ast.Node) bool {
+		expr, ok := node.(ast.Expr)
+		if !ok || seen[node] {
+			return true
+		}
+
+		// @step: unwind the indexes i.e. Cloud.latestAMI["filter"]["owners"]
+		var arguments []ast.Expr
+		for {
+			index, ok := expr.(*ast.IndexExpr)
+			if !ok {
+				break
+			}
+			seen[index] = true
+			arguments = append([]ast.Expr{index.Index}, arguments...)
+			expr = index.X
+		}
+
+		selector, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := selector.X.(*ast.Ident)
+		if !ok || ident.Name != models.RenderKeyCloud {
+			return true
+		}
+		name, _, err := ast.LabelName(selector.Sel)
+		if err != nil {
+			return true
+		}
+		seen[selector] = true
+		list = append(list, cueReference{name: name, arguments: arguments})
+
+		return true
+	}, nil)
+
+	return list
+}
+
+// setCUELookup places the result of the lookup under the keys, the same lookup cannot be referenced
+// with both fewer and more arguments
+func setCUELookup(lookups map[string]interface{}, keys []string, value interface{}) error {
+	current := lookups
+	for _, key := range keys[:len(keys)-1] {
+		v, found := current[key]
+		if !found {
+			v = make(map[string]interface{}, 0)
+			current[key] = v
+		}
+		next, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("lookup: %s referenced with conflicting arguments", keys[0])
+		}
+		current = next
+	}
+
+	key := keys[len(keys)-1]
+	if v, found := current[key]; found && !reflect.DeepEqual(v, value) {
+		return fmt.Errorf("lookup: %s referenced with conflicting arguments", keys[0])
+	}
+	current[key] = value
+
+	return nil
+}
+
+// toCUEArgument converts the index into the argument of the lookup
+func toCUEArgument(param, value string) interface{} {
+	if !cueListParams[param] {
+		return value
+	}
+
+	var list []interface{}
+	for _, x := range strings.Split(value, ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			list = append(list, x)
+		}
+	}
+
+	return list
+}
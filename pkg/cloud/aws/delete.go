@@ -52,5 +52,5 @@ func (p *provider) Delete(ctx context.Context, name string, _ *models.DeleteOpti
 	// @step: kick off the deletion of the stack
 	_, err = p.client.DeleteStack(&cloudformation.DeleteStackInput{StackName: aws.String(name)})
 
-	return err
+	return classifyError(err)
 }
@@ -0,0 +1,40 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// Diff is responsible for comparing the deployed stack with the template rendered from the options
+func (p *provider) Diff(ctx context.Context, name string, options *models.CreateOptions) (*apiv1.StackDiff, error) {
+	stack, err := p.Get(ctx, name, &models.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	rendered := options.Content
+	if rendered == "" {
+		if rendered, err = p.Render(ctx, options); err != nil {
+			return nil, err
+		}
+	}
+
+	return models.DiffStack(stack, rendered, options.Tags)
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// publicSubnetTag is the tag used to mark the public subnets of the cluster
+	publicSubnetTag = "kubernetes.io/role/elb"
+	// privateSubnetTag is the tag used to mark the private subnets of the cluster
+	privateSubnetTag = "kubernetes.io/role/internal-elb"
+)
+
+// defaultImageOwners are the owners of the images searched by latestAMI when none are given
+var defaultImageOwners = []string{"self", "amazon"}
+
+// AccountID returns the account id of the credentials
+func (t *Templater) AccountID() (string, error) {
+	v, err := t.lookup("accountID", "", func() (interface{}, error) {
+		resp, err := t.clients.Identity.GetCallerIdentityWithContext(t.ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return nil, err
+		}
+
+		return aws.StringValue(resp.Account), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// AvailabilityZones returns the names of the available zones in the region
+func (t *Templater) AvailabilityZones() ([]string, error) {
+	v, err := t.lookup("availabilityZones", "", func() (interface{}, error) {
+		resp, err := t.clients.Compute.DescribeAvailabilityZonesWithContext(t.ctx, &ec2.DescribeAvailabilityZonesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("state"),
+					Values: []*string{aws.String("available")},
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var list []string
+		for _, x := range resp.AvailabilityZones {
+			list = append(list, aws.StringValue(x.ZoneName))
+		}
+		sort.Strings(list)
+
+		return list, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]string), nil
+}
+
+// HostedZone returns the hosted zone for the domain
+func (t *Templater) HostedZone(name string) (models.HostedZone, error) {
+	domain := strings.TrimSuffix(name, ".") + "."
+
+	v, err := t.lookup("hostedZone", domain, func() (interface{}, error) {
+		resp, err := t.clients.DNS.ListHostedZonesByNameWithContext(t.ctx, &route53.ListHostedZonesByNameInput{
+			DNSName: aws.String(domain),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var list []models.HostedZone
+		for _, x := range resp.HostedZones {
+			if aws.StringValue(x.Name) != domain {
+				continue
+			}
+			zone := models.HostedZone{
+				ID:   strings.TrimPrefix(aws.StringValue(x.Id), "/hostedzone/"),
+				Name: strings.TrimSuffix(domain, "."),
+			}
+			if x.Config != nil {
+				zone.Private = aws.BoolValue(x.Config.PrivateZone)
+			}
+			list = append(list, zone)
+		}
+		switch len(list) {
+		case 0:
+			return nil, fmt.Errorf("hosted zone: %s, %s", name, ErrLookupNotFound)
+		case 1:
+		default:
+			return nil, fmt.Errorf("hosted zone: %s, %s", name, ErrLookupAmbiguous)
+		}
+
+		return list[0], nil
+	})
+	if err != nil {
+		return models.HostedZone{}, err
+	}
+
+	return v.(models.HostedZone), nil
+}
+
+// KMSKeyArn returns the arn of the kms key for the alias
+func (t *Templater) KMSKeyArn(alias string) (string, error) {
+	if !strings.HasPrefix(alias, "alias/") {
+		alias = "alias/" + alias
+	}
+
+	v, err := t.lookup("kmsKeyArn", alias, func() (interface{}, error) {
+		resp, err := t.clients.Keys.DescribeKeyWithContext(t.ctx, &kms.DescribeKeyInput{
+			KeyId: aws.String(alias),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.KeyMetadata == nil {
+			return nil, fmt.Errorf("kms key: %s, %s", alias, ErrLookupNotFound)
+		}
+
+		return aws.StringValue(resp.KeyMetadata.Arn), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// LatestAMI returns the id of the most recent image matching the name filter owned by the owners
+// i.e. amazon or an account id; by default the images of the account and amazon are searched, never
+// all the public images
+func (t *Templater) LatestAMI(filter string, owners ...string) (string, error) {
+	if len(owners) <= 0 {
+		owners = defaultImageOwners
+	}
+	key := fmt.Sprintf("%s/%s", filter, strings.Join(owners, ","))
+
+	v, err := t.lookup("latestAMI", key, func() (interface{}, error) {
+		input := &ec2.DescribeImagesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("name"),
+					Values: []*string{aws.String(filter)},
+				},
+				{
+					Name:   aws.String("state"),
+					Values: []*string{aws.String("available")},
+				},
+			},
+		}
+		input.Owners = aws.StringSlice(owners)
+		resp, err := t.clients.Compute.DescribeImagesWithContext(t.ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Images) <= 0 {
+			return nil, fmt.Errorf("image: %s, %s", filter, ErrLookupNotFound)
+		}
+
+		// @step: the creation date is iso8601 so sorts lexically
+		images := resp.Images
+		sort.Slice(images, func(i, j int) bool {
+			return aws.StringValue(images[i].CreationDate) > aws.StringValue(images[j].CreationDate)
+		})
+
+		return aws.StringValue(images[0].ImageId), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// Network returns the vpc of the cluster
+func (t *Templater) Network() (models.Network, error) {
+	v, err := t.lookup("vpc", "", func() (interface{}, error) {
+		resp, err := t.clients.Compute.DescribeVpcsWithContext(t.ctx, &ec2.DescribeVpcsInput{
+			Filters: getClusterFilters(t.config.ClusterName),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Vpcs) <= 0 {
+			return nil, fmt.Errorf("vpc: %s", ErrLookupNotFound)
+		}
+		if len(resp.Vpcs) > 1 {
+			return nil, fmt.Errorf("vpc: %s", ErrLookupAmbiguous)
+		}
+		tags := makeTags(resp.Vpcs[0].Tags)
+
+		return models.Network{
+			CIDR: aws.StringValue(resp.Vpcs[0].CidrBlock),
+			Object: models.Object{
+				ID:   aws.StringValue(resp.Vpcs[0].VpcId),
+				Name: tags["Name"],
+				Tags: tags,
+			},
+		}, nil
+	})
+	if err != nil {
+		return models.Network{}, err
+	}
+
+	return v.(models.Network), nil
+}
+
+// NetworkID return the vpc id
+func (t *Templater) NetworkID() (string, error) {
+	network, err := t.Network()
+
+	return network.ID, err
+}
+
+// RouteTables returns the route tables of the cluster
+func (t *Templater) RouteTables() ([]models.RouteTable, error) {
+	v, err := t.lookup("routeTables", "", func() (interface{}, error) {
+		resp, err := t.clients.Compute.DescribeRouteTablesWithContext(t.ctx, &ec2.DescribeRouteTablesInput{
+			Filters: getClusterFilters(t.config.ClusterName),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var list []models.RouteTable
+		for _, x := range resp.RouteTables {
+			tags := makeTags(x.Tags)
+			table := models.RouteTable{
+				NetworkID: aws.StringValue(x.VpcId),
+				Object: models.Object{
+					ID:   aws.StringValue(x.RouteTableId),
+					Name: tags["Name"],
+					Tags: tags,
+				},
+			}
+			for _, a := range x.Associations {
+				if a.SubnetId != nil {
+					table.Subnets = append(table.Subnets, aws.StringValue(a.SubnetId))
+				}
+			}
+			list = append(list, table)
+		}
+
+		return list, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]models.RouteTable), nil
+}
+
+// SecurityGroups returns the security groups of the cluster
+func (t *Templater) SecurityGroups() ([]models.SecurityGroup, error) {
+	v, err := t.lookup("securityGroups", "", func() (interface{}, error) {
+		resp, err := t.clients.Compute.DescribeSecurityGroupsWithContext(t.ctx, &ec2.DescribeSecurityGroupsInput{
+			Filters: getClusterFilters(t.config.ClusterName),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var list []models.SecurityGroup
+		for _, x := range resp.SecurityGroups {
+			tags := makeTags(x.Tags)
+			list = append(list, models.SecurityGroup{
+				Description: aws.StringValue(x.Description),
+				NetworkID:   aws.StringValue(x.VpcId),
+				Object: models.Object{
+					ID:   aws.StringValue(x.GroupId),
+					Name: aws.StringValue(x.GroupName),
+					Tags: tags,
+				},
+			})
+		}
+
+		return list, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]models.SecurityGroup), nil
+}
+
+// Subnets returns a list of subnets within the VPC
+func (t *Templater) Subnets() ([]models.Network, error) {
+	v, err := t.lookup("subnets", "", func() (interface{}, error) {
+		resp, err := t.clients.Compute.DescribeSubnetsWithContext(t.ctx, &ec2.DescribeSubnetsInput{
+			Filters: getClusterFilters(t.config.ClusterName),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var list []models.Network
+		for _, x := range resp.Subnets {
+			tags := makeTags(x.Tags)
+			list = append(list, models.Network{
+				AvailabilityZone: aws.StringValue(x.AvailabilityZone),
+				CIDR:             aws.StringValue(x.CidrBlock),
+				Object: models.Object{
+					ID:   aws.StringValue(x.SubnetId),
+					Name: tags["Name"],
+					Tags: tags,
+				},
+			})
+		}
+
+		return list, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]models.Network), nil
+}
+
+// SubnetsWithTag returns the subnets of the cluster which have the tag, optionally with one of the values
+func (t *Templater) SubnetsWithTag(key string, values ...string) ([]models.Network, error) {
+	subnets, err := t.Subnets()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []models.Network
+	for _, x := range subnets {
+		value, found := x.Tags[key]
+		if !found {
+			continue
+		}
+		if len(values) > 0 && !containsString(values, value) {
+			continue
+		}
+		list = append(list, x)
+	}
+
+	return list, nil
+}
+
+// PublicSubnets returns the subnets of the cluster tagged for public load balancers
+func (t *Templater) PublicSubnets() ([]models.Network, error) {
+	return t.SubnetsWithTag(publicSubnetTag)
+}
+
+// PrivateSubnets returns the subnets of the cluster tagged for internal load balancers
+func (t *Templater) PrivateSubnets() ([]models.Network, error) {
+	return t.SubnetsWithTag(privateSubnetTag)
+}
+
+// containsString checks if the value is in the list
+func containsString(list []string, value string) bool {
+	for _, x := range list {
+		if x == value {
+			return true
+		}
+	}
+
+	return false
+}
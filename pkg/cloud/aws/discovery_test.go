@@ -0,0 +1,270 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+type fakeEC2 struct {
+	ec2iface.EC2API
+	calls  map[string]int
+	owners []string
+}
+
+func (f *fakeEC2) DescribeAvailabilityZonesWithContext(ctx aws.Context, input *ec2.DescribeAvailabilityZonesInput, opts ...request.Option) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	f.calls["zones"]++
+	return &ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("eu-west-2b")},
+			{ZoneName: aws.String("eu-west-2a")},
+		},
+	}, nil
+}
+
+func (f *fakeEC2) DescribeImagesWithContext(ctx aws.Context, input *ec2.DescribeImagesInput, opts ...request.Option) (*ec2.DescribeImagesOutput, error) {
+	f.calls["images"]++
+	f.owners = aws.StringValueSlice(input.Owners)
+	return &ec2.DescribeImagesOutput{
+		Images: []*ec2.Image{
+			{ImageId: aws.String("ami-old"), CreationDate: aws.String("2018-01-01T00:00:00.000Z")},
+			{ImageId: aws.String("ami-new"), CreationDate: aws.String("2018-06-01T00:00:00.000Z")},
+		},
+	}, nil
+}
+
+func (f *fakeEC2) DescribeRouteTablesWithContext(ctx aws.Context, input *ec2.DescribeRouteTablesInput, opts ...request.Option) (*ec2.DescribeRouteTablesOutput, error) {
+	f.calls["routes"]++
+	return &ec2.DescribeRouteTablesOutput{
+		RouteTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-1"),
+				VpcId:        aws.String("vpc-1"),
+				Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-1")}, {Main: aws.Bool(true)}},
+			},
+		},
+	}, nil
+}
+
+func (f *fakeEC2) DescribeSecurityGroupsWithContext(ctx aws.Context, input *ec2.DescribeSecurityGroupsInput, opts ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
+	f.calls["groups"]++
+	return &ec2.DescribeSecurityGroupsOutput{
+		SecurityGroups: []*ec2.SecurityGroup{
+			{GroupId: aws.String("sg-1"), GroupName: aws.String("nodes"), VpcId: aws.String("vpc-1")},
+		},
+	}, nil
+}
+
+func (f *fakeEC2) DescribeSubnetsWithContext(ctx aws.Context, input *ec2.DescribeSubnetsInput, opts ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
+	f.calls["subnets"]++
+	return &ec2.DescribeSubnetsOutput{
+		Subnets: []*ec2.Subnet{
+			{
+				SubnetId: aws.String("subnet-1"),
+				Tags:     []*ec2.Tag{{Key: aws.String(publicSubnetTag), Value: aws.String("1")}},
+			},
+			{
+				SubnetId: aws.String("subnet-2"),
+				Tags:     []*ec2.Tag{{Key: aws.String(privateSubnetTag), Value: aws.String("1")}},
+			},
+		},
+	}, nil
+}
+
+type fakeRoute53 struct {
+	route53iface.Route53API
+}
+
+func (f *fakeRoute53) ListHostedZonesByNameWithContext(ctx aws.Context, input *route53.ListHostedZonesByNameInput, opts ...request.Option) (*route53.ListHostedZonesByNameOutput, error) {
+	return &route53.ListHostedZonesByNameOutput{
+		HostedZones: []*route53.HostedZone{
+			{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}},
+			{Id: aws.String("/hostedzone/Z2"), Name: aws.String("sub.example.com.")},
+		},
+	}, nil
+}
+
+type fakeKMS struct {
+	kmsiface.KMSAPI
+}
+
+func (f *fakeKMS) DescribeKeyWithContext(ctx aws.Context, input *kms.DescribeKeyInput, opts ...request.Option) (*kms.DescribeKeyOutput, error) {
+	return &kms.DescribeKeyOutput{
+		KeyMetadata: &kms.KeyMetadata{Arn: aws.String("arn:aws:kms:eu-west-2:123456789012:key/" + aws.StringValue(input.KeyId))},
+	}, nil
+}
+
+type fakeSTS struct {
+	stsiface.STSAPI
+}
+
+func (f *fakeSTS) GetCallerIdentityWithContext(ctx aws.Context, input *sts.GetCallerIdentityInput, opts ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil
+}
+
+func newTestTemplater() (*Templater, *fakeEC2) {
+	compute := &fakeEC2{calls: make(map[string]int, 0)}
+	t := NewTemplater(TemplaterClients{
+		Compute:  compute,
+		DNS:      &fakeRoute53{},
+		Identity: &fakeSTS{},
+		Keys:     &fakeKMS{},
+	}, &models.ProviderConfig{ClusterName: "test", Region: "eu-west-2"})
+	t.ctx = context.TODO()
+
+	return t, compute
+}
+
+func TestDiscoveryFuncs(t *testing.T) {
+	templater, _ := newTestTemplater()
+
+	account, err := templater.AccountID()
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012", account)
+
+	zones, err := templater.AvailabilityZones()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-west-2a", "eu-west-2b"}, zones)
+
+	zone, err := templater.HostedZone("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, models.HostedZone{ID: "Z1", Name: "example.com", Private: true}, zone)
+
+	arn, err := templater.KMSKeyArn("secrets")
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:kms:eu-west-2:123456789012:key/alias/secrets", arn)
+
+	ami, err := templater.LatestAMI("amzn2-ami-hvm-*", "amazon")
+	require.NoError(t, err)
+	assert.Equal(t, "ami-new", ami)
+
+	routes, err := templater.RouteTables()
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, []string{"subnet-1"}, routes[0].Subnets)
+
+	groups, err := templater.SecurityGroups()
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "sg-1", groups[0].ID)
+}
+
+func TestSubnetFiltering(t *testing.T) {
+	templater, _ := newTestTemplater()
+
+	subnets, err := templater.Subnets()
+	require.NoError(t, err)
+	assert.Len(t, subnets, 2)
+
+	public, err := templater.PublicSubnets()
+	require.NoError(t, err)
+	if assert.Len(t, public, 1) {
+		assert.Equal(t, "subnet-1", public[0].ID)
+	}
+	private, err := templater.PrivateSubnets()
+	require.NoError(t, err)
+	if assert.Len(t, private, 1) {
+		assert.Equal(t, "subnet-2", private[0].ID)
+	}
+	tagged, err := templater.SubnetsWithTag(publicSubnetTag, "0")
+	require.NoError(t, err)
+	assert.Len(t, tagged, 0)
+}
+
+func TestDiscoveryCachedPerRender(t *testing.T) {
+	templater, compute := newTestTemplater()
+	content := "Subnets:\n{{- range publicSubnets }}\n- {{ .ID }}\n{{- end }}\n{{- range privateSubnets }}\n- {{ .ID }}\n{{- end }}\n"
+
+	_, err := templater.Render(context.TODO(), &models.RenderContext{}, content, apiv1.FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, 1, compute.calls["subnets"])
+
+	// @step: a new templater does not share the cache
+	templater, compute = newTestTemplater()
+	_, err = templater.Subnets()
+	require.NoError(t, err)
+	assert.Equal(t, 1, compute.calls["subnets"])
+}
+
+func TestDiscoveryCachedAcrossRenders(t *testing.T) {
+	cache := NewLookupCache(time.Minute)
+
+	templater, compute := newTestTemplater()
+	_, err := templater.WithCache(cache).Subnets()
+	require.NoError(t, err)
+
+	// @step: a new templater sharing the cache should not call the api
+	templater, _ = newTestTemplater()
+	templater.clients.Compute = compute
+	_, err = templater.WithCache(cache).Subnets()
+	require.NoError(t, err)
+	assert.Equal(t, 1, compute.calls["subnets"])
+
+	// @step: once expired the lookup is performed again
+	cache.items["subnets/"] = cachedLookup{expires: time.Now().Add(-time.Second)}
+	templater, _ = newTestTemplater()
+	templater.clients.Compute = compute
+	_, err = templater.WithCache(cache).Subnets()
+	require.NoError(t, err)
+	assert.Equal(t, 2, compute.calls["subnets"])
+}
+
+func TestDiscoveryLookupError(t *testing.T) {
+	templater, _ := newTestTemplater()
+	content := "Zone: {{ (hostedZone \"missing.com\").ID }}\n"
+
+	_, err := templater.Render(context.TODO(), &models.RenderContext{}, content, apiv1.FormatYAML)
+	require.Error(t, err)
+	assert.True(t, IsLookupError(err))
+	assert.Equal(t, "hostedZone", err.(*LookupError).Function)
+
+	// @step: the failed lookup should not be cached
+	_, err = templater.HostedZone("missing.com")
+	assert.Error(t, err)
+}
+
+func TestLatestAMIDefaultOwners(t *testing.T) {
+	templater, compute := newTestTemplater()
+
+	// @check the images are never searched across all the public images
+	ami, err := templater.LatestAMI("amzn2-ami-hvm-*")
+	require.NoError(t, err)
+	assert.Equal(t, "ami-new", ami)
+	assert.Equal(t, []string{"self", "amazon"}, compute.owners)
+
+	_, err = templater.LatestAMI("amzn2-ami-hvm-*", "123456789012")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"123456789012"}, compute.owners)
+}
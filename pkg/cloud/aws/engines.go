@@ -0,0 +1,141 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/cloud"
+)
+
+// discoveryFunc is a lookup exposed to the engines other than go templates
+type discoveryFunc struct {
+	// name is the name of the function
+	name string
+	// params are the names of the arguments
+	params []string
+	// call performs the lookup, the arguments are strings or lists of strings
+	call func(args []interface{}) (interface{}, error)
+}
+
+// newRenderer returns the renderer for the engine
+func newRenderer(engine string, t *Templater) (cloud.Renderer, error) {
+	switch engine {
+	case "", apiv1.EngineGoTemplate:
+		return t, nil
+	case apiv1.EngineJsonnet:
+		return &jsonnetRenderer{templater: t}, nil
+	case apiv1.EngineCUE:
+		return &cueRenderer{templater: t}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported rendering engine: %s", engine)
+}
+
+// discoveryFuncs returns the lookups of the templater in a form usable by the engines
+func (t *Templater) discoveryFuncs() []discoveryFunc {
+	return []discoveryFunc{
+		{name: "accountID", call: func([]interface{}) (interface{}, error) { return t.AccountID() }},
+		{name: "availabilityZones", call: func([]interface{}) (interface{}, error) { return t.AvailabilityZones() }},
+		{name: "hostedZone", params: []string{"name"}, call: func(args []interface{}) (interface{}, error) {
+			return t.HostedZone(argString(args, 0))
+		}},
+		{name: "kmsKeyArn", params: []string{"alias"}, call: func(args []interface{}) (interface{}, error) {
+			return t.KMSKeyArn(argString(args, 0))
+		}},
+		{name: "latestAMI", params: []string{"filter", "owners"}, call: func(args []interface{}) (interface{}, error) {
+			return t.LatestAMI(argString(args, 0), argStrings(args, 1)...)
+		}},
+		{name: "privateSubnets", call: func([]interface{}) (interface{}, error) { return t.PrivateSubnets() }},
+		{name: "publicSubnets", call: func([]interface{}) (interface{}, error) { return t.PublicSubnets() }},
+		{name: "region", call: func([]interface{}) (interface{}, error) { return t.Region(), nil }},
+		{name: "routeTables", call: func([]interface{}) (interface{}, error) { return t.RouteTables() }},
+		{name: "securityGroups", call: func([]interface{}) (interface{}, error) { return t.SecurityGroups() }},
+		{name: "subnets", call: func([]interface{}) (interface{}, error) { return t.Subnets() }},
+		{name: "subnetsWithTag", params: []string{"key", "values"}, call: func(args []interface{}) (interface{}, error) {
+			return t.SubnetsWithTag(argString(args, 0), argStrings(args, 1)...)
+		}},
+		{name: "vpc", call: func([]interface{}) (interface{}, error) { return t.Network() }},
+		{name: "vpcid", call: func([]interface{}) (interface{}, error) { return t.NetworkID() }},
+	}
+}
+
+// argString returns the argument as a string
+func argString(args []interface{}, i int) string {
+	if i >= len(args) || args[i] == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", args[i])
+}
+
+// argStrings returns the argument as a list of strings, a single value or a list is accepted
+func argStrings(args []interface{}, i int) []string {
+	if i >= len(args) || args[i] == nil {
+		return nil
+	}
+	switch v := args[i].(type) {
+	case []interface{}:
+		var list []string
+		for _, x := range v {
+			list = append(list, fmt.Sprintf("%v", x))
+		}
+		return list
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	}
+
+	return []string{fmt.Sprintf("%v", args[i])}
+}
+
+// toGeneric converts the value into the generic json types i.e. maps, slices and scalars
+func toGeneric(v interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// fromJSON converts the json document produced by an engine into the format of the template
+func fromJSON(content []byte, format string) (string, error) {
+	switch format {
+	case apiv1.FormatYAML:
+		encoded, err := yaml.JSONToYAML(content)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	case apiv1.FormatJSON:
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	return "", fmt.Errorf("unsupported format: %s", format)
+}
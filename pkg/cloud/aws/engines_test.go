@@ -0,0 +1,101 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+func TestNewRenderer(t *testing.T) {
+	templater, _ := newTestTemplater()
+	for _, x := range apiv1.Engines {
+		_, err := newRenderer(x, templater)
+		assert.NoError(t, err, "engine: %s", x)
+	}
+	_, err := newRenderer("unknown", templater)
+	assert.Error(t, err)
+}
+
+func TestJsonnetRender(t *testing.T) {
+	templater, _ := newTestTemplater()
+	renderer, err := newRenderer(apiv1.EngineJsonnet, templater)
+	require.NoError(t, err)
+
+	content := `{
+  Bucket: std.extVar('bucket'),
+  Owner: std.extVar('Resource').Namespace + '/' + std.extVar('Params').bucket,
+  Subnets: [x.ID for x in std.native('publicSubnets')()],
+  Zone: std.native('hostedZone')('example.com').ID,
+}`
+	rendered, err := renderer.Render(context.TODO(), newTestRenderContext(), content, apiv1.FormatJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Bucket":"test","Owner":"apps/test","Subnets":["subnet-1"],"Zone":"Z1"}`, rendered)
+
+	_, err = renderer.Render(context.TODO(), newTestRenderContext(), `{ Zone: std.native('hostedZone')('missing.com') }`, apiv1.FormatJSON)
+	assert.True(t, IsLookupError(err))
+
+	assert.Error(t, renderer.Parse(`{ Bucket: }`))
+}
+
+func TestCUERender(t *testing.T) {
+	templater, compute := newTestTemplater()
+	renderer, err := newRenderer(apiv1.EngineCUE, templater)
+	require.NoError(t, err)
+
+	content := `Bucket: Params.bucket
+Owner: "\(Resource.Namespace)/\(Params.bucket)"
+Subnets: [ for x in Cloud.publicSubnets { x.ID } ]
+`
+	rendered, err := renderer.Render(context.TODO(), newTestRenderContext(), content, apiv1.FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "Bucket: test\nOwner: apps/test\nSubnets:\n- subnet-1\n", rendered)
+
+	// @step: only the lookups referenced should be performed
+	assert.Equal(t, 1, compute.calls["subnets"])
+	assert.Equal(t, 0, compute.calls["zones"])
+
+	assert.Error(t, renderer.Parse(`Bucket: {`))
+}
+
+func TestCUERenderLookupArguments(t *testing.T) {
+	templater, compute := newTestTemplater()
+	renderer, err := newRenderer(apiv1.EngineCUE, templater)
+	require.NoError(t, err)
+
+	content := `// Cloud.subnets is not referenced
+Note: "Cloud.availabilityZones"
+Zone: Cloud.hostedZone["example.com"].ID
+Image: Cloud.latestAMI[Params.bucket]["self, amazon"]
+`
+	rendered, err := renderer.Render(context.TODO(), newTestRenderContext(), content, apiv1.FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "Note: Cloud.availabilityZones\nZone: Z1\nImage: ami-new\n", rendered)
+	assert.Equal(t, []string{"self", "amazon"}, compute.owners)
+
+	// @step: the references are taken from the cue rather than the text of the content
+	assert.Equal(t, 0, compute.calls["subnets"])
+	assert.Equal(t, 0, compute.calls["zones"])
+
+	_, err = renderer.Render(context.TODO(), newTestRenderContext(), "Image: Cloud.latestAMI\n", apiv1.FormatYAML)
+	assert.Error(t, err)
+}
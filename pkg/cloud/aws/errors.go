@@ -0,0 +1,134 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// errorClasses maps the error codes of the aws services to the error classes
+var errorClasses = map[string]string{
+	"AccessDenied":                      models.ErrorClassUnauthorized,
+	"AccessDeniedException":             models.ErrorClassUnauthorized,
+	"AlreadyExistsException":            models.ErrorClassConflict,
+	"ConcurrentModification":            models.ErrorClassConflict,
+	"EntityAlreadyExists":               models.ErrorClassConflict,
+	"ExpiredToken":                      models.ErrorClassUnauthorized,
+	"ExpiredTokenException":             models.ErrorClassUnauthorized,
+	"InsufficientCapabilitiesException": models.ErrorClassInvalidTemplate,
+	"InternalError":                     models.ErrorClassTransient,
+	"InternalFailure":                   models.ErrorClassTransient,
+	"InvalidClientTokenId":              models.ErrorClassUnauthorized,
+	"LimitExceeded":                     models.ErrorClassQuotaExceeded,
+	"LimitExceededException":            models.ErrorClassQuotaExceeded,
+	"MalformedPolicyDocument":           models.ErrorClassInvalidTemplate,
+	"NoSuchEntity":                      models.ErrorClassNotFound,
+	"OperationInProgressException":      models.ErrorClassConflict,
+	"RequestCanceled":                   models.ErrorClassTransient,
+	"RequestError":                      models.ErrorClassTransient,
+	"RequestExpired":                    models.ErrorClassTransient,
+	"RequestLimitExceeded":              models.ErrorClassThrottled,
+	"RequestTimeout":                    models.ErrorClassTransient,
+	"RequestTimeoutException":           models.ErrorClassTransient,
+	"ServiceUnavailable":                models.ErrorClassTransient,
+	"SignatureDoesNotMatch":             models.ErrorClassUnauthorized,
+	"StackNotFoundException":            models.ErrorClassNotFound,
+	"Throttling":                        models.ErrorClassThrottled,
+	"ThrottlingException":               models.ErrorClassThrottled,
+	"TokenAlreadyExistsException":       models.ErrorClassConflict,
+	"TooManyRequestsException":          models.ErrorClassThrottled,
+	"UnauthorizedOperation":             models.ErrorClassUnauthorized,
+	"UnrecognizedClientException":       models.ErrorClassUnauthorized,
+}
+
+const (
+	// validationErrorCode is the error code cloudformation uses for most of the rejected requests
+	validationErrorCode = "ValidationError"
+	// noUpdatesMessage is the message of the validation error when the stack already matches the update
+	noUpdatesMessage = "No updates are to be performed"
+)
+
+// validationErrorClasses are the validation errors from cloudformation which can be classified; the
+// service uses the one error code for a missing stack, a stack in progress, an unchanged stack and an
+// invalid request alike, and only the message tells them apart. Any other validation error is unknown,
+// and so retried, as it may well be caused by the state of the stack rather than the template
+var validationErrorClasses = []struct {
+	// Class is the class of the error
+	Class string
+	// Message is a fragment of the message given by cloudformation
+	Message string
+}{
+	{Class: models.ErrorClassNotFound, Message: "does not exist"},
+	{Class: models.ErrorClassConflict, Message: "_IN_PROGRESS state"},
+}
+
+// classifyError converts an error from the aws services into a classified error
+func classifyError(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case context.Canceled, context.DeadlineExceeded:
+		return models.NewError(models.ErrorClassTransient, "", err)
+	}
+	if _, ok := err.(*models.Error); ok {
+		return err
+	}
+	e, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+
+	class, found := errorClasses[e.Code()]
+	if !found {
+		class = models.ErrorClassUnknown
+		if x, ok := err.(awserr.RequestFailure); ok && x.StatusCode() >= 500 {
+			class = models.ErrorClassTransient
+		}
+	}
+	if e.Code() == validationErrorCode {
+		for _, x := range validationErrorClasses {
+			if strings.Contains(e.Message(), x.Message) {
+				class = x.Class
+				break
+			}
+		}
+	}
+
+	return models.NewError(class, e.Code(), err)
+}
+
+// classifyTemplateError converts an error from validating a template into a classified error, here a
+// validation error can only mean the template has been rejected
+func classifyTemplateError(err error) error {
+	if e, ok := err.(awserr.Error); ok && e.Code() == validationErrorCode {
+		return models.NewError(models.ErrorClassInvalidTemplate, e.Code(), err)
+	}
+
+	return classifyError(err)
+}
+
+// isNoUpdatesError checks if the error is cloudformation refusing an update which changes nothing
+func isNoUpdatesError(err error) bool {
+	e, ok := err.(awserr.Error)
+
+	return ok && e.Code() == validationErrorCode && strings.Contains(e.Message(), noUpdatesMessage)
+}
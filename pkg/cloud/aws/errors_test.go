@@ -0,0 +1,101 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		Err   error
+		Class string
+	}{
+		{
+			Err:   awserr.New("ValidationError", "Stack with id test does not exist", nil),
+			Class: models.ErrorClassNotFound,
+		},
+		{
+			Err:   awserr.New("ValidationError", "Stack:test is in UPDATE_IN_PROGRESS state and can not be updated.", nil),
+			Class: models.ErrorClassConflict,
+		},
+		{
+			Err:   awserr.New("ValidationError", "Parameter 'Name' must be one of AllowedValues", nil),
+			Class: models.ErrorClassUnknown,
+		},
+		{
+			Err:   awserr.New("Throttling", "Rate exceeded", nil),
+			Class: models.ErrorClassThrottled,
+		},
+		{
+			Err:   awserr.New("AccessDenied", "User is not authorized to perform: cloudformation:CreateStack", nil),
+			Class: models.ErrorClassUnauthorized,
+		},
+		{
+			Err:   awserr.New("LimitExceededException", "Limit on the number of stacks has been exceeded", nil),
+			Class: models.ErrorClassQuotaExceeded,
+		},
+		{
+			Err:   awserr.New("InsufficientCapabilitiesException", "Requires capabilities : [CAPABILITY_NAMED_IAM]", nil),
+			Class: models.ErrorClassInvalidTemplate,
+		},
+		{
+			Err:   awserr.NewRequestFailure(awserr.New("Unexpected", "bad gateway", nil), 502, "id"),
+			Class: models.ErrorClassTransient,
+		},
+		{
+			Err:   awserr.New("Unexpected", "unexpected", nil),
+			Class: models.ErrorClassUnknown,
+		},
+		{
+			Err:   context.DeadlineExceeded,
+			Class: models.ErrorClassTransient,
+		},
+		{
+			Err:   errors.New("not an aws error"),
+			Class: models.ErrorClassUnknown,
+		},
+	}
+	for i, c := range cases {
+		err := classifyError(c.Err)
+		assert.Equal(t, c.Class, models.GetErrorClass(err), "case %d", i)
+		assert.Equal(t, c.Err.Error(), err.Error(), "case %d", i)
+	}
+	assert.NoError(t, classifyError(nil))
+}
+
+func TestClassifyTemplateError(t *testing.T) {
+	err := classifyTemplateError(awserr.New("ValidationError", "Template format error: Unresolved resource dependencies", nil))
+	assert.Equal(t, models.ErrorClassInvalidTemplate, models.GetErrorClass(err))
+
+	err = classifyTemplateError(awserr.New("Throttling", "Rate exceeded", nil))
+	assert.Equal(t, models.ErrorClassThrottled, models.GetErrorClass(err))
+}
+
+func TestIsNoUpdatesError(t *testing.T) {
+	assert.True(t, isNoUpdatesError(awserr.New("ValidationError", "No updates are to be performed.", nil)))
+	assert.False(t, isNoUpdatesError(awserr.New("ValidationError", "Stack with id test does not exist", nil)))
+	assert.False(t, isNoUpdatesError(errors.New("No updates are to be performed.")))
+	assert.False(t, isNoUpdatesError(nil))
+}
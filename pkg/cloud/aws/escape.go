@@ -0,0 +1,468 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/ghodss/yaml"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const (
+	// escapeJSONQuote is the escaper for values outside of a string
+	escapeJSONQuote = "jsonquote"
+	// escapeYAMLQuote is the escaper for values outside of a string
+	escapeYAMLQuote = "yamlquote"
+	// escapeDoubleQuoted is the escaper for values inside a double quoted string
+	escapeDoubleQuoted = "escapedq"
+	// escapeSingleQuoted is the escaper for values inside a yaml single quoted string
+	escapeSingleQuoted = "escapesq"
+	// escapePlain is the escaper for values placed within a plain scalar
+	escapePlain = "escapeplain"
+	// escapeFlow is the escaper for values placed within a plain scalar in a flow collection
+	escapeFlow = "escapeflow"
+	// escapeRaw is used by template authors to opt out of escaping
+	escapeRaw = "raw"
+	// escapePlaceholder is the value output in place of the parameters when checking the structure
+	escapePlaceholder = "placeholder"
+)
+
+// quoting is the quoting context at a position in the template
+type quoting int
+
+const (
+	quoteNone quoting = iota
+	quoteDouble
+	quoteSingle
+	quoteComment
+)
+
+// quoteScanner tracks the quoting context through the text of a template; a quote only opens
+// a scalar where a value may begin and a comment runs to the end of the line
+type quoteScanner struct {
+	// state is the quoting context at the current position
+	state quoting
+	// value indicates a scalar may begin at the current position
+	value bool
+	// indicator indicates a ':', '-' or tag was seen and a value begins after whitespace
+	indicator bool
+	// tag indicates we are within a tag i.e. !Sub
+	tag bool
+	// spaced indicates the previous character was whitespace
+	spaced bool
+	// escaped indicates the previous character was a backslash in a double quoted scalar
+	escaped bool
+	// closing indicates a quote was seen in a single quoted scalar; it is either the end
+	// of the scalar or the first of an escaped pair
+	closing bool
+	// flow is the depth of the flow collections i.e. [ ] and { } at the current position
+	flow int
+}
+
+// newQuoteScanner returns a scanner positioned at the start of a document
+func newQuoteScanner() *quoteScanner {
+	return &quoteScanner{value: true, spaced: true}
+}
+
+// escapeFuncsMap returns the escaping functions available to the templates
+func escapeFuncsMap() template.FuncMap {
+	return template.FuncMap{
+		escapeDoubleQuoted: escapeDoubleQuotedValue,
+		escapeFlow:         escapeFlowValue,
+		escapeJSONQuote:    quoteValue,
+		escapePlain:        escapePlainValue,
+		escapeRaw:          func(v interface{}) string { return fmt.Sprintf("%v", v) },
+		escapeSingleQuoted: escapeSingleQuotedValue,
+		escapeYAMLQuote:    quoteValue,
+	}
+}
+
+// placeholderFuncsMap returns the escaping and encoding functions outputting a placeholder in place of
+// the value; a template rendered with these has the resources the author wrote, no parameter having been output
+func placeholderFuncsMap() template.FuncMap {
+	encoders := escapeFuncsMap()
+	encoders[encodeJSON] = toJSON
+	encoders[encodeYAML] = toYAML
+
+	funcs := make(template.FuncMap, len(encoders))
+	for k, v := range encoders {
+		placeholder := reflect.ValueOf(v).Call([]reflect.Value{reflect.ValueOf(escapePlaceholder)})[0].String()
+		funcs[k] = func(interface{}) string {
+			return placeholder
+		}
+	}
+
+	return funcs
+}
+
+// quoteValue returns the value as a double quoted string; a json string is also a valid
+// yaml double quoted scalar so the same encoding serves both formats
+func quoteValue(v interface{}) string {
+	encoded, _ := json.Marshal(fmt.Sprintf("%v", v))
+
+	return string(encoded)
+}
+
+// escapeDoubleQuotedValue escapes a value placed inside an existing double quoted string
+func escapeDoubleQuotedValue(v interface{}) string {
+	quoted := quoteValue(v)
+
+	return quoted[1 : len(quoted)-1]
+}
+
+// escapeSingleQuotedValue escapes a value placed inside an existing yaml single quoted string
+func escapeSingleQuotedValue(v interface{}) string {
+	return strings.Replace(fmt.Sprintf("%v", v), "'", "''", -1)
+}
+
+// escapePlainValue checks a value placed within a plain scalar i.e. Name: {{ .env }}-bucket; a plain
+// scalar has no escaping so a value which would end the scalar is refused
+func escapePlainValue(v interface{}) (string, error) {
+	value := fmt.Sprintf("%v", v)
+	for _, x := range []string{"\n", "\r", ": ", ":\t", " #", "\t#"} {
+		if strings.Contains(value, x) {
+			return "", fmt.Errorf("value: %q cannot be placed within an unquoted scalar", value)
+		}
+	}
+	if strings.HasSuffix(value, ":") {
+		return "", fmt.Errorf("value: %q cannot be placed within an unquoted scalar", value)
+	}
+
+	return value, nil
+}
+
+// escapeFlowValue checks a value placed within a plain scalar in a flow collection i.e. [ a-{{ .env }} ]
+func escapeFlowValue(v interface{}) (string, error) {
+	value, err := escapePlainValue(v)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(value, ",[]{}") {
+		return "", fmt.Errorf("value: %q cannot be placed within an unquoted scalar in a flow collection", value)
+	}
+
+	return value, nil
+}
+
+// escapeTemplate walks the parse tree and appends a context aware escaper to every action
+// which outputs a parameter i.e. {{ .param }}
+func escapeTemplate(tm *template.Template, format string) {
+	for _, x := range tm.Templates() {
+		if x.Tree == nil || x.Tree.Root == nil {
+			continue
+		}
+		// @note: the end of the document ends any scalar, so is treated as a newline
+		escapeList(x.Tree.Root, format, newQuoteScanner(), "\n")
+	}
+}
+
+// escapeList is responsible for walking the nodes of a list; the text following an action decides if
+// the action is the whole of a scalar, the following text of the list being used for the last node
+func escapeList(n *parse.ListNode, format string, scanner *quoteScanner, following string) {
+	if n == nil {
+		return
+	}
+	for i, x := range n.Nodes {
+		next := following
+		if i < len(n.Nodes)-1 {
+			next = ""
+			if text, ok := n.Nodes[i+1].(*parse.TextNode); ok {
+				next = string(text.Text)
+			}
+		}
+		escapeNode(x, format, scanner, next)
+	}
+}
+
+// escapeNode is responsible for walking a node in the template tree; each branch of a conditional
+// is walked from the context preceding it
+func escapeNode(node parse.Node, format string, scanner *quoteScanner, following string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		escapeList(n, format, scanner, following)
+	case *parse.TextNode:
+		scanner.scan(string(n.Text))
+	case *parse.ActionNode:
+		escapeAction(n, format, scanner.context(), scanner.isScalar(following), scanner.flow > 0)
+		scanner.output()
+	case *parse.IfNode:
+		escapeBranches(n.List, n.ElseList, format, scanner, following)
+	case *parse.RangeNode:
+		escapeBranches(n.List, n.ElseList, format, scanner, following)
+	case *parse.WithNode:
+		escapeBranches(n.List, n.ElseList, format, scanner, following)
+	}
+}
+
+// escapeBranches walks the branches of a conditional each with a copy of the preceding context; the
+// context following the conditional is taken from the first branch
+func escapeBranches(list, elseList *parse.ListNode, format string, scanner *quoteScanner, following string) {
+	preceding := *scanner
+
+	escapeList(list, format, scanner, following)
+
+	branch := preceding
+	escapeList(elseList, format, &branch, following)
+}
+
+// escapeAction appends the escaper to the action if it outputs data from the render context; an
+// action forming the whole of a scalar is quoted, otherwise it is escaped within the scalar
+func escapeAction(n *parse.ActionNode, format string, state quoting, scalar, flow bool) {
+	pipe := n.Pipe
+	if pipe == nil || len(pipe.Decl) > 0 || len(pipe.Cmds) <= 0 {
+		return
+	}
+	// @check the pipeline references the render data i.e. a parameter
+	if !referencesData(pipe) {
+		return
+	}
+	// @check the pipeline has not already been escaped or encoded by the author
+	for _, cmd := range pipe.Cmds {
+		if len(cmd.Args) <= 0 {
+			continue
+		}
+		if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok && isEscaper(ident.Ident) {
+			return
+		}
+	}
+
+	var escaper string
+	switch state {
+	case quoteDouble:
+		escaper = escapeDoubleQuoted
+	case quoteSingle:
+		escaper = escapeSingleQuoted
+	case quoteNone:
+		switch {
+		case !scalar && flow:
+			escaper = escapeFlow
+		case !scalar:
+			escaper = escapePlain
+		case format == apiv1.FormatJSON:
+			escaper = escapeJSONQuote
+		default:
+			escaper = escapeYAMLQuote
+		}
+	default:
+		escaper = escapeYAMLQuote
+	}
+
+	pipe.Cmds = append(pipe.Cmds, &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Pos:      pipe.Position(),
+		Args:     []parse.Node{parse.NewIdentifier(escaper).SetPos(pipe.Position())},
+	})
+}
+
+// referencesData checks if any of the arguments in the pipeline refer to the render data
+func referencesData(pipe *parse.PipeNode) bool {
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			switch a := arg.(type) {
+			case *parse.FieldNode, *parse.ChainNode, *parse.VariableNode, *parse.DotNode:
+				return true
+			case *parse.PipeNode:
+				if referencesData(a) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// isEscaper checks if the function escapes or encodes its output
+func isEscaper(name string) bool {
+	switch name {
+	case escapeDoubleQuoted, escapeFlow, escapeJSONQuote, escapePlain, escapeRaw, escapeSingleQuoted, escapeYAMLQuote,
+		encodeJSON, encodeYAML, includeTemplate:
+		return true
+	}
+
+	return false
+}
+
+// scan tracks the quoting context through a block of text; quoting never spans a newline
+// in the documents we render so the state is reset on each line
+func (s *quoteScanner) scan(text string) {
+	for _, c := range text {
+		s.next(c)
+	}
+}
+
+// next moves the scanner over a single character
+func (s *quoteScanner) next(c rune) {
+	if s.closing {
+		s.closing = false
+		if c == '\'' {
+			return
+		}
+		s.state = quoteNone
+	}
+
+	switch {
+	case c == '\n':
+		*s = quoteScanner{value: true, spaced: true, flow: s.flow}
+	case s.state == quoteComment:
+	case s.state == quoteDouble:
+		switch {
+		case s.escaped:
+			s.escaped = false
+		case c == '\\':
+			s.escaped = true
+		case c == '"':
+			s.state = quoteNone
+		}
+	case s.state == quoteSingle:
+		s.closing = c == '\''
+	case c == ' ' || c == '\t':
+		if s.indicator {
+			s.value = true
+		}
+		s.indicator, s.tag, s.spaced = false, false, true
+	case c == '#' && s.spaced:
+		s.state = quoteComment
+	case c == '"' && (s.value || s.indicator):
+		s.state = quoteDouble
+		s.value, s.indicator, s.spaced = false, false, false
+	case c == '\'' && (s.value || s.indicator):
+		s.state = quoteSingle
+		s.value, s.indicator, s.spaced = false, false, false
+	case c == ':' && !s.tag:
+		s.value, s.indicator, s.spaced = false, true, false
+	case (c == '-' || c == '!') && s.value:
+		s.value, s.indicator, s.tag, s.spaced = false, true, c == '!', false
+	case c == '[' || c == '{':
+		s.value, s.indicator, s.spaced = true, false, false
+		s.flow++
+	case c == ',':
+		s.value, s.indicator, s.spaced = true, false, false
+	case (c == ']' || c == '}') && s.flow > 0:
+		s.value, s.indicator, s.spaced = false, false, false
+		s.flow--
+	default:
+		s.value, s.spaced = false, false
+		s.indicator = s.tag
+	}
+}
+
+// isScalar checks if an action at the current position followed by the text forms the whole of a
+// scalar, i.e. the scalar begins here and the text ends it
+func (s *quoteScanner) isScalar(text string) bool {
+	if s.state != quoteNone || !s.value {
+		return false
+	}
+	trimmed := strings.TrimLeft(text, " \t")
+	switch {
+	case trimmed == "":
+		return false
+	case trimmed[0] == '\n' || trimmed[0] == '\r':
+		return true
+	case trimmed[0] == '#':
+		return len(trimmed) < len(text)
+	case trimmed[0] == ':':
+		return len(trimmed) == 1 || strings.ContainsAny(trimmed[1:2], " \t\r\n")
+	case s.flow > 0:
+		return strings.ContainsAny(trimmed[0:1], ",]}")
+	}
+
+	return false
+}
+
+// context returns the quoting context at the current position
+func (s *quoteScanner) context() quoting {
+	if s.closing {
+		return quoteNone
+	}
+
+	return s.state
+}
+
+// output moves the scanner over the output of an action; outside of a quoted scalar the
+// output is a value so a quote following it is literal
+func (s *quoteScanner) output() {
+	if s.closing {
+		s.closing = false
+		s.state = quoteNone
+	}
+	if s.state == quoteNone {
+		s.value, s.indicator, s.tag, s.spaced = false, false, false, false
+	}
+}
+
+// getTemplateResources returns a map of logical resource name to type from a rendered template
+func getTemplateResources(content string) (map[string]string, error) {
+	encoded, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the rendered template: %s", err)
+	}
+	document := struct {
+		Resources map[string]struct {
+			Type string `json:"Type"`
+		} `json:"Resources"`
+	}{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, fmt.Errorf("unable to decode the rendered template: %s", err)
+	}
+
+	list := make(map[string]string, len(document.Resources))
+	for k, v := range document.Resources {
+		list[k] = v.Type
+	}
+
+	return list, nil
+}
+
+// checkTemplateStructure ensures the rendered template produces the same resources as a render with
+// the parameters output as placeholders, i.e. the parameters have not injected resources
+func checkTemplateStructure(rendered, expected string) error {
+	actual, err := getTemplateResources(rendered)
+	if err != nil {
+		return err
+	}
+	wanted, err := getTemplateResources(expected)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(actual, wanted) {
+		return nil
+	}
+
+	var diff []string
+	for k, v := range actual {
+		if wanted[k] != v {
+			diff = append(diff, fmt.Sprintf("%s(%s)", k, v))
+		}
+	}
+	for k, v := range wanted {
+		if _, found := actual[k]; !found {
+			diff = append(diff, fmt.Sprintf("%s(%s)", k, v))
+		}
+	}
+	sort.Strings(diff)
+
+	return fmt.Errorf("rendered template resources differ from the expected set: %s", strings.Join(diff, ","))
+}
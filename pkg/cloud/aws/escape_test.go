@@ -0,0 +1,158 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const testInjectedValue = "bucket\nResources:\n  User:\n    Type: AWS::IAM::User"
+
+func renderEscaped(t *testing.T, content, format string, values interface{}) string {
+	return renderTemplate(t, content, format, values, escapeFuncsMap())
+}
+
+func renderPlaceholders(t *testing.T, content, format string, values interface{}) string {
+	return renderTemplate(t, content, format, values, placeholderFuncsMap())
+}
+
+func renderTemplate(t *testing.T, content, format string, values interface{}, escapers template.FuncMap) string {
+	tm := template.New("main").Funcs(libraryFuncsMap()).Funcs(escapeFuncsMap())
+	_, err := tm.Parse(content)
+	require.NoError(t, err)
+	escapeTemplate(tm, format)
+	tm.Funcs(escapers)
+
+	writer := new(bytes.Buffer)
+	require.NoError(t, tm.ExecuteTemplate(writer, "main", values))
+
+	return writer.String()
+}
+
+func TestEscapeTemplateContexts(t *testing.T) {
+	cases := []struct {
+		Content  string
+		Format   string
+		Expected string
+	}{
+		{Content: `Name: {{ .value }}`, Format: apiv1.FormatYAML, Expected: `Name: "a'b\"c"`},
+		{Content: `Name: "x-{{ .value }}"`, Format: apiv1.FormatYAML, Expected: `Name: "x-a'b\"c"`},
+		{Content: `Name: 'x-{{ .value }}'`, Format: apiv1.FormatYAML, Expected: `Name: 'x-a''b"c'`},
+		{Content: `{"Name": {{ .value }}}`, Format: apiv1.FormatJSON, Expected: `{"Name": "a'b\"c"}`},
+		{Content: `{"Name": "{{ .value }}"}`, Format: apiv1.FormatJSON, Expected: `{"Name": "a'b\"c"}`},
+		{Content: `Name: {{ .value | raw }}`, Format: apiv1.FormatYAML, Expected: `Name: a'b"c`},
+		{Content: `Name: {{ .value | yamlquote }}`, Format: apiv1.FormatYAML, Expected: `Name: "a'b\"c"`},
+		{Content: `Name: {{ default "none" .value }}`, Format: apiv1.FormatYAML, Expected: `Name: "a'b\"c"`},
+		{Content: `Name: {{ .value | upper }}`, Format: apiv1.FormatYAML, Expected: `Name: "A'B\"C"`},
+		{Content: `Name: {{ .value | toJson }}`, Format: apiv1.FormatYAML, Expected: `Name: "a'b\"c"`},
+		{Content: `Region: {{ "eu-west-2" }}`, Format: apiv1.FormatYAML, Expected: `Region: eu-west-2`},
+		{Content: `Description: Bob's bucket {{ .value }}`, Format: apiv1.FormatYAML, Expected: `Description: Bob's bucket a'b"c`},
+		{Content: `Name: {{ .value }}-bucket`, Format: apiv1.FormatYAML, Expected: `Name: a'b"c-bucket`},
+		{Content: `Arn: arn:aws:s3:::{{ .value }}`, Format: apiv1.FormatYAML, Expected: `Arn: arn:aws:s3:::a'b"c`},
+		{Content: `Tags: [ {{ .value }}, x-{{ .value }} ]`, Format: apiv1.FormatYAML, Expected: `Tags: [ "a'b\"c", x-a'b"c ]`},
+		{Content: `{{ .value }}: x`, Format: apiv1.FormatYAML, Expected: `"a'b\"c": x`},
+		{Content: `Name: {{ if .flag }}'x'{{ else }}{{ .value }}{{ end }}`, Format: apiv1.FormatYAML, Expected: `Name: "a'b\"c"`},
+		{Content: "{{ range .list }}\n- {{ . }}{{ end }}\n", Format: apiv1.FormatYAML, Expected: "\n- \"a\"\n- \"b\"\n"},
+		{Content: "# it's the name\nName: {{ .value }}", Format: apiv1.FormatYAML, Expected: "# it's the name\nName: \"a'b\\\"c\""},
+		{Content: `Name: {{ .value }} # it's the name`, Format: apiv1.FormatYAML, Expected: `Name: "a'b\"c" # it's the name`},
+		{Content: `Name: !Sub 'x-{{ .value }}'`, Format: apiv1.FormatYAML, Expected: `Name: !Sub 'x-a''b"c'`},
+		{Content: `Name: 'it''s {{ .value }}'`, Format: apiv1.FormatYAML, Expected: `Name: 'it''s a''b"c'`},
+		{Content: `Tags: [ "a", '{{ .value }}' ]`, Format: apiv1.FormatYAML, Expected: `Tags: [ "a", 'a''b"c' ]`},
+		{Content: `- "{{ .value }}"`, Format: apiv1.FormatYAML, Expected: `- "a'b\"c"`},
+	}
+	for i, c := range cases {
+		rendered := renderEscaped(t, c.Content, c.Format, map[string]interface{}{"flag": false, "list": []string{"a", "b"}, "value": `a'b"c`})
+		assert.Equal(t, c.Expected, rendered, "case %d, content: %s", i, c.Content)
+	}
+}
+
+func TestEscapePlainValue(t *testing.T) {
+	for _, x := range []string{"a\nb", "a: b", "a #b", "a:"} {
+		_, err := escapePlainValue(x)
+		assert.Error(t, err, "value: %q", x)
+	}
+	_, err := escapeFlowValue("a,b")
+	assert.Error(t, err)
+
+	value, err := escapePlainValue("a:b#c")
+	assert.NoError(t, err)
+	assert.Equal(t, "a:b#c", value)
+}
+
+func TestQuoteScanner(t *testing.T) {
+	cases := []struct {
+		Text     string
+		Expected quoting
+	}{
+		{Text: `Name: `, Expected: quoteNone},
+		{Text: `Name: "`, Expected: quoteDouble},
+		{Text: `Name: "a\"`, Expected: quoteDouble},
+		{Text: `Name: '`, Expected: quoteSingle},
+		{Text: `Name: 'it'`, Expected: quoteNone},
+		{Text: `Name: 'it''`, Expected: quoteSingle},
+		{Text: `Name: 'it''s `, Expected: quoteSingle},
+		{Text: `Name: Bob's `, Expected: quoteNone},
+		{Text: `Name: "a" '`, Expected: quoteNone},
+		{Text: `# it's `, Expected: quoteComment},
+		{Text: "# it's\nName: ", Expected: quoteNone},
+		{Text: "Name: \"\n", Expected: quoteNone},
+		{Text: `Name: !Sub "`, Expected: quoteDouble},
+		{Text: `{"Name":"`, Expected: quoteDouble},
+	}
+	for i, c := range cases {
+		scanner := newQuoteScanner()
+		scanner.scan(c.Text)
+		assert.Equal(t, c.Expected, scanner.context(), "case %d, text: %s", i, c.Text)
+	}
+}
+
+func TestCheckTemplateStructure(t *testing.T) {
+	content := "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n    Properties:\n      BucketName: {{ .bucket }}\n"
+	values := map[string]string{"bucket": testInjectedValue}
+
+	expected := renderPlaceholders(t, content, apiv1.FormatYAML, values)
+	escaped := renderEscaped(t, content, apiv1.FormatYAML, values)
+	assert.NoError(t, checkTemplateStructure(escaped, expected))
+
+	content = "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n    Properties:\n      BucketName: {{ .bucket | raw }}\n"
+	raw := renderEscaped(t, content, apiv1.FormatYAML, values)
+	assert.Error(t, checkTemplateStructure(raw, renderPlaceholders(t, content, apiv1.FormatYAML, values)))
+}
+
+func TestCheckTemplateStructureEncoded(t *testing.T) {
+	content := "Resources: {{ .resources | toJson }}\n"
+	values := map[string]interface{}{"resources": map[string]interface{}{"User": map[string]string{"Type": "AWS::IAM::User"}}}
+
+	rendered := renderEscaped(t, content, apiv1.FormatYAML, values)
+	assert.Error(t, checkTemplateStructure(rendered, renderPlaceholders(t, content, apiv1.FormatYAML, values)))
+}
+
+func TestCheckTemplateStructureConditional(t *testing.T) {
+	content := "Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n{{- if eq .env \"prod\" }}\n  Replica:\n    Type: AWS::S3::Bucket\n{{- end }}\n"
+	for _, env := range []string{"dev", "prod"} {
+		values := map[string]string{"env": env}
+		rendered := renderEscaped(t, content, apiv1.FormatYAML, values)
+		assert.NoError(t, checkTemplateStructure(rendered, renderPlaceholders(t, content, apiv1.FormatYAML, values)), "env: %s", env)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// encodeJSON is the function encoding a value as json
+	encodeJSON = "toJson"
+	// encodeYAML is the function encoding a value as yaml
+	encodeYAML = "toYaml"
+	// includeTemplate is the function rendering a define block, the output of which is escaped
+	includeTemplate = "include"
+)
+
+// unsafeFuncs are functions from the library which must not be exposed to the template
+// authors, i.e. reading the environment of the controller would expose the credentials
+var unsafeFuncs = []string{"env", "expandenv"}
+
+// libraryFuncsMap returns the general purpose functions available to the templates
+func libraryFuncsMap() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	for _, x := range unsafeFuncs {
+		delete(funcs, x)
+	}
+	funcs[encodeJSON] = toJSON
+	funcs[encodeYAML] = toYAML
+	funcs["required"] = required
+	funcs["sha256"] = sha256sum
+	funcs["uuid"] = funcs["uuidv4"]
+
+	return funcs
+}
+
+// toJSON encodes the value as json
+func toJSON(v interface{}) (string, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// toYAML encodes the value as yaml
+func toYAML(v interface{}) (string, error) {
+	encoded, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(encoded), "\n"), nil
+}
+
+// required fails the render if the value is empty
+func required(message string, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, errors.New(message)
+	}
+	if s, ok := v.(string); ok && s == "" {
+		return nil, errors.New(message)
+	}
+
+	return v, nil
+}
+
+// sha256sum returns the hex encoded sha256 of the value
+func sha256sum(v interface{}) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v", v)))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -49,7 +49,7 @@ func (p *provider) getStack(ctx context.Context, name string) (*cloudformation.S
 		StackName: aws.String(name),
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if err = classifyError(err); models.IsErrorClass(err, models.ErrorClassNotFound) {
 			return nil, "", models.ErrStackNotFound
 		}
 
@@ -76,7 +76,7 @@ func (p *provider) getStackTemplate(ctx context.Context, name string) (string, e
 		StackName: aws.String(name),
 	})
 	if err != nil {
-		return "", err
+		return "", classifyError(err)
 	}
 
 	if resp.TemplateBody == nil {
@@ -122,7 +122,7 @@ func (p *provider) getAccessToken(ctx context.Context, username string) (string,
 		UserName: aws.String(username),
 	})
 	if err != nil {
-		return "", "", err
+		return "", "", classifyError(err)
 	}
 	// @check if we have reached the max number of keys
 	if len(resp.AccessKeyMetadata) >= maxAccessKeys {
@@ -133,7 +133,7 @@ func (p *provider) getAccessToken(ctx context.Context, username string) (string,
 		UserName: aws.String(username),
 	})
 	if err != nil {
-		return "", "", err
+		return "", "", classifyError(err)
 	}
 	if res.AccessKey == nil {
 		return "", "", fmt.Errorf("no access returns in response for user: %s", username)
@@ -152,7 +152,7 @@ func (p *provider) getPolicyArn(ctx context.Context, name string) (string, error
 		Scope: aws.String("LOCAL"),
 	})
 	if err != nil {
-		return "", err
+		return "", classifyError(err)
 	}
 	for _, x := range resp.Policies {
 		if name == aws.StringValue(x.PolicyName) {
@@ -173,7 +173,7 @@ func (p *provider) findIAMUsers(ctx context.Context, name string) ([]string, err
 		StackName: aws.String(name),
 	})
 	if err != nil {
-		return list, err
+		return list, classifyError(err)
 	}
 
 	for _, x := range resp.StackResources {
@@ -218,13 +218,11 @@ func makeStack(stack *cloudformation.Stack, body string) (*models.Stack, error)
 		Name:    aws.StringValue(stack.StackName),
 		Created: aws.TimeValue(stack.CreationTime),
 		Spec: models.StackSpec{
-			Outputs:  make(map[string]string, 0),
-			Tags:     make(map[string]string, 0),
-			Template: body,
-		},
-		Status: models.StackStatus{
-			Status: getStackStatus(aws.StringValue(stack.StackStatus)),
+			Content: body,
+			Outputs: make(map[string]string, 0),
+			Tags:    make(map[string]string, 0),
 		},
+		Status: getStackStatus(stack),
 	}
 
 	// @step: copy the outputs from the stack
@@ -277,44 +275,73 @@ func checkForTags(key, value string, tags []*cloudformation.Tag) bool {
 	return false
 }
 
-// getStackStatus coverts the cloudformation status a stack status
-func getStackStatus(status string) string {
+// getStackStatus converts the cloudformation stack to a stack status
+func getStackStatus(stack *cloudformation.Stack) models.StackStatus {
+	status := models.StackStatus{
+		LastTransitionTime: aws.TimeValue(stack.CreationTime),
+		Phase:              getStackPhase(aws.StringValue(stack.StackStatus)),
+		ProviderStatus:     aws.StringValue(stack.StackStatus),
+		Reason:             aws.StringValue(stack.StackStatusReason),
+	}
+	if stack.LastUpdatedTime != nil {
+		status.LastTransitionTime = aws.TimeValue(stack.LastUpdatedTime)
+	}
+	if stack.DeletionTime != nil {
+		status.LastTransitionTime = aws.TimeValue(stack.DeletionTime)
+	}
+
+	return status
+}
+
+// getStackPhase converts the cloudformation status to the phase of the stack; a stack whose creation
+// was rolled back cannot be updated, only deleted, so is failed rather than rolled back
+func getStackPhase(status string) string {
 	switch status {
 	case "CREATE_COMPLETE":
-		return models.StatusDone
-	case "CREATE_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseReady
 	case "CREATE_FAILED":
-		return models.StatusFailed
+		return models.PhaseFailed
+	case "CREATE_IN_PROGRESS":
+		return models.PhaseCreating
 	case "DELETE_COMPLETE":
-		return models.StatusDone
+		return models.PhaseDeleted
 	case "DELETE_FAILED":
-		return models.StatusFailed
+		return models.PhaseFailed
 	case "DELETE_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseDeleting
+	case "IMPORT_COMPLETE":
+		return models.PhaseReady
+	case "IMPORT_IN_PROGRESS":
+		return models.PhaseUpdating
+	case "IMPORT_ROLLBACK_COMPLETE":
+		return models.PhaseRolledBack
+	case "IMPORT_ROLLBACK_FAILED":
+		return models.PhaseFailed
+	case "IMPORT_ROLLBACK_IN_PROGRESS":
+		return models.PhaseRollingBack
 	case "REVIEW_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseCreating
 	case "ROLLBACK_COMPLETE":
-		return models.StatusDone
+		return models.PhaseFailed
 	case "ROLLBACK_FAILED":
-		return models.StatusFailed
+		return models.PhaseFailed
 	case "ROLLBACK_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseRollingBack
 	case "UPDATE_COMPLETE":
-		return models.StatusDone
+		return models.PhaseReady
 	case "UPDATE_COMPLETE_CLEANUP_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseUpdating
 	case "UPDATE_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseUpdating
 	case "UPDATE_ROLLBACK_COMPLETE":
-		return models.StatusDone
+		return models.PhaseRolledBack
 	case "UPDATE_ROLLBACK_COMPLETE_CLEANUP_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseRollingBack
 	case "UPDATE_ROLLBACK_FAILED":
-		return models.StatusDone
+		return models.PhaseFailed
 	case "UPDATE_ROLLBACK_IN_PROGRESS":
-		return models.StatusInProgress
+		return models.PhaseRollingBack
 	}
 
-	return ""
+	return models.PhaseUnknown
 }
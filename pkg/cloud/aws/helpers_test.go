@@ -0,0 +1,85 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func TestGetStackPhase(t *testing.T) {
+	cases := map[string]string{
+		cloudformation.StackStatusCreateComplete:                          models.PhaseReady,
+		cloudformation.StackStatusCreateFailed:                            models.PhaseFailed,
+		cloudformation.StackStatusCreateInProgress:                        models.PhaseCreating,
+		cloudformation.StackStatusDeleteComplete:                          models.PhaseDeleted,
+		cloudformation.StackStatusDeleteFailed:                            models.PhaseFailed,
+		cloudformation.StackStatusDeleteInProgress:                        models.PhaseDeleting,
+		cloudformation.StackStatusImportComplete:                          models.PhaseReady,
+		cloudformation.StackStatusImportInProgress:                        models.PhaseUpdating,
+		cloudformation.StackStatusImportRollbackComplete:                  models.PhaseRolledBack,
+		cloudformation.StackStatusImportRollbackFailed:                    models.PhaseFailed,
+		cloudformation.StackStatusImportRollbackInProgress:                models.PhaseRollingBack,
+		cloudformation.StackStatusReviewInProgress:                        models.PhaseCreating,
+		cloudformation.StackStatusRollbackComplete:                        models.PhaseFailed,
+		cloudformation.StackStatusRollbackFailed:                          models.PhaseFailed,
+		cloudformation.StackStatusRollbackInProgress:                      models.PhaseRollingBack,
+		cloudformation.StackStatusUpdateComplete:                          models.PhaseReady,
+		cloudformation.StackStatusUpdateCompleteCleanupInProgress:         models.PhaseUpdating,
+		cloudformation.StackStatusUpdateInProgress:                        models.PhaseUpdating,
+		cloudformation.StackStatusUpdateRollbackComplete:                  models.PhaseRolledBack,
+		cloudformation.StackStatusUpdateRollbackCompleteCleanupInProgress: models.PhaseRollingBack,
+		cloudformation.StackStatusUpdateRollbackFailed:                    models.PhaseFailed,
+		cloudformation.StackStatusUpdateRollbackInProgress:                models.PhaseRollingBack,
+		"NOT_A_STATUS": models.PhaseUnknown,
+	}
+	for status, expected := range cases {
+		assert.Equal(t, expected, getStackPhase(status), "status: %s", status)
+	}
+}
+
+func TestGetStackStatus(t *testing.T) {
+	created := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	status := getStackStatus(&cloudformation.Stack{
+		CreationTime:      aws.Time(created),
+		LastUpdatedTime:   aws.Time(updated),
+		StackStatus:       aws.String("UPDATE_ROLLBACK_COMPLETE"),
+		StackStatusReason: aws.String("resource failed to update"),
+	})
+	assert.Equal(t, models.StackStatus{
+		LastTransitionTime: updated,
+		Phase:              models.PhaseRolledBack,
+		ProviderStatus:     "UPDATE_ROLLBACK_COMPLETE",
+		Reason:             "resource failed to update",
+	}, status)
+	assert.False(t, status.IsInProgress())
+
+	status = getStackStatus(&cloudformation.Stack{
+		CreationTime: aws.Time(created),
+		StackStatus:  aws.String("CREATE_IN_PROGRESS"),
+	})
+	assert.Equal(t, created, status.LastTransitionTime)
+	assert.True(t, status.IsInProgress())
+}
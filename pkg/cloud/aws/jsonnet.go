@@ -0,0 +1,108 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonnet "github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// jsonnetFilename is the name used for the content in error messages
+	jsonnetFilename = "template.jsonnet"
+)
+
+// jsonnetRenderer evaluates the content as jsonnet; the render context is available as external
+// variables i.e. std.extVar("Params").name and the lookups as native functions i.e. std.native("subnets")()
+type jsonnetRenderer struct {
+	templater *Templater
+}
+
+// Parse checks the content is valid jsonnet
+func (j *jsonnetRenderer) Parse(content string) error {
+	if _, err := jsonnet.SnippetToAST(jsonnetFilename, content); err != nil {
+		return fmt.Errorf("unable to parse the template: %s", err)
+	}
+
+	return nil
+}
+
+// Render evaluates the jsonnet and returns the document in the format; the parameters are
+// passed as values rather than substituted into the content so no escaping is required
+func (j *jsonnetRenderer) Render(ctx context.Context, data *models.RenderContext, content, format string) (string, error) {
+	j.templater.ctx = ctx
+	j.templater.err = nil
+
+	capture := prometheus.NewTimer(templateDuration)
+	defer capture.ObserveDuration()
+
+	vm := jsonnet.MakeVM()
+	for k, v := range data.Values() {
+		if s, ok := v.(string); ok {
+			vm.ExtVar(k, s)
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		vm.ExtCode(k, string(encoded))
+	}
+	for _, x := range j.templater.discoveryFuncs() {
+		fn := x
+		vm.NativeFunction(&jsonnet.NativeFunction{
+			Name:   fn.name,
+			Params: makeIdentifiers(fn.params),
+			Func: func(args []interface{}) (interface{}, error) {
+				v, err := fn.call(args)
+				if err != nil {
+					return nil, err
+				}
+
+				return toGeneric(v)
+			},
+		})
+	}
+
+	generated, err := vm.EvaluateSnippet(jsonnetFilename, content)
+	if err != nil {
+		if j.templater.err != nil {
+			return "", j.templater.err
+		}
+
+		return "", err
+	}
+
+	return fromJSON([]byte(generated), format)
+}
+
+// makeIdentifiers converts the names to jsonnet identifiers
+func makeIdentifiers(names []string) ast.Identifiers {
+	var list ast.Identifiers
+	for _, x := range names {
+		list = append(list, ast.Identifier(x))
+	}
+
+	return list
+}
@@ -36,7 +36,7 @@ func (p *provider) List(ctx context.Context, options *models.ListOptions) ([]*mo
 
 	resp, err := p.client.ListStacksWithContext(ctx, &cloudformation.ListStacksInput{})
 	if err != nil {
-		return list, err
+		return list, classifyError(err)
 	}
 	metric.ObserveDuration()
 
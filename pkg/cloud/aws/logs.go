@@ -31,17 +31,33 @@ import (
 
 // Logs retrieves the logs from the cloudformation stack
 func (p *provider) Logs(ctx context.Context, name string, options *models.GetOptions) (string, error) {
-	_, found, err := p.Exists(ctx, name)
+	events, err := p.Events(ctx, name, options)
 	if err != nil {
 		return "", err
 	}
+
+	var b bytes.Buffer
+	b.WriteString("\n")
+	for _, x := range events {
+		b.WriteString(fmt.Sprintf("%s %s %s %s\n", x.Status, x.Type, x.LogicalID, x.Reason))
+	}
+
+	return b.String(), nil
+}
+
+// Events retrieves the events from the cloudformation stack, most recent first
+func (p *provider) Events(ctx context.Context, name string, options *models.GetOptions) ([]models.StackEvent, error) {
+	_, found, err := p.Exists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
 	if !found {
-		return "", models.ErrStackNotFound
+		return nil, models.ErrStackNotFound
 	}
 
 	log.WithFields(log.Fields{
 		"stackname": name,
-	}).Debug("retrieving the cloudformation logs for stack")
+	}).Debug("retrieving the cloudformation events for stack")
 
 	tm := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
 		requestDuration.WithLabelValues("logs").Observe(v)
@@ -50,19 +66,22 @@ func (p *provider) Logs(ctx context.Context, name string, options *models.GetOpt
 		StackName: aws.String(name),
 	})
 	if err != nil {
-		return "", err
+		return nil, classifyError(err)
 	}
 	tm.ObserveDuration()
 
-	var b bytes.Buffer
-	b.WriteString("\n")
+	var list []models.StackEvent
 	for _, x := range resp.StackEvents {
-		b.WriteString(fmt.Sprintf("%s %s %s %s\n",
-			aws.StringValue(x.ResourceStatus),
-			aws.StringValue(x.ResourceType),
-			aws.StringValue(x.LogicalResourceId),
-			aws.StringValue(x.ResourceStatusReason)))
+		list = append(list, models.StackEvent{
+			ID:         aws.StringValue(x.EventId),
+			LogicalID:  aws.StringValue(x.LogicalResourceId),
+			PhysicalID: aws.StringValue(x.PhysicalResourceId),
+			Reason:     aws.StringValue(x.ResourceStatusReason),
+			Status:     aws.StringValue(x.ResourceStatus),
+			Time:       aws.TimeValue(x.Timestamp),
+			Type:       aws.StringValue(x.ResourceType),
+		})
 	}
 
-	return b.String(), nil
+	return list, nil
 }
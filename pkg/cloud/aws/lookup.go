@@ -0,0 +1,138 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLookupNotFound indicates the lookup did not find a matching resource
+	ErrLookupNotFound = errors.New("no matching resource found")
+	// ErrLookupAmbiguous indicates the lookup found more than one matching resource
+	ErrLookupAmbiguous = errors.New("more than one matching resource found")
+)
+
+// LookupError is returned when a template lookup against the cloud apis fails
+type LookupError struct {
+	// Function is the template function which failed
+	Function string
+	// Err is the underlying error
+	Err error
+}
+
+// Error returns the error message
+func (e *LookupError) Error() string {
+	return fmt.Sprintf("template function: %s failed, error: %s", e.Function, e.Err)
+}
+
+// IsLookupError checks if the error is from a template lookup
+func IsLookupError(err error) bool {
+	_, ok := err.(*LookupError)
+
+	return ok
+}
+
+// cachedLookup is the result of a lookup held in the cache
+type cachedLookup struct {
+	// expires is when the value is no longer valid
+	expires time.Time
+	// value is the result of the lookup
+	value interface{}
+}
+
+// LookupCache holds the results of lookups across renders for a period of time
+type LookupCache struct {
+	sync.Mutex
+	// items are the cached lookups
+	items map[string]cachedLookup
+	// ttl is the duration a result is held
+	ttl time.Duration
+}
+
+// NewLookupCache creates a cache holding the lookups for the duration
+func NewLookupCache(ttl time.Duration) *LookupCache {
+	return &LookupCache{items: make(map[string]cachedLookup, 0), ttl: ttl}
+}
+
+// get returns the lookup from the cache if present and not expired
+func (c *LookupCache) get(key string) (interface{}, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	item, found := c.items[key]
+	if !found {
+		return nil, false
+	}
+	if time.Now().After(item.expires) {
+		delete(c.items, key)
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+// set adds the lookup to the cache
+func (c *LookupCache) set(key string, value interface{}) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.items[key] = cachedLookup{expires: time.Now().Add(c.ttl), value: value}
+}
+
+// lookup returns the result of a previous lookup in this render or the shared cache, else
+// performs the lookup; a failure is recorded so the render returns the typed error
+func (t *Templater) lookup(function, arguments string, fn func() (interface{}, error)) (interface{}, error) {
+	key := function + "/" + arguments
+
+	if v, found := t.cache[key]; found {
+		lookupTotal.WithLabelValues(function, "render").Inc()
+		return v, nil
+	}
+	// @check a stubbed templater never calls the cloud apis
+	if t.stubs != nil {
+		fn = func() (interface{}, error) { return getStubbedLookup(t.stubs, function, arguments) }
+	} else if t.shared != nil {
+		if v, found := t.shared.get(key); found {
+			lookupTotal.WithLabelValues(function, "cache").Inc()
+			t.cache[key] = v
+			return v, nil
+		}
+	}
+
+	v, err := fn()
+	if err != nil {
+		lookupErrorTotal.WithLabelValues(function).Inc()
+		err = &LookupError{Function: function, Err: err}
+		if t.err == nil {
+			t.err = err
+		}
+
+		return nil, err
+	}
+	lookupTotal.WithLabelValues(function, "api").Inc()
+
+	t.cache[key] = v
+	if t.shared != nil && t.stubs == nil {
+		t.shared.set(key, v)
+	}
+
+	return v, nil
+}
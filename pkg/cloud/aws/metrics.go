@@ -26,6 +26,20 @@ var (
 		},
 		[]string{"type"},
 	)
+	lookupErrorTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cloud_template_lookup_errors_total",
+			Help: "The total number of failed template lookups by function",
+		},
+		[]string{"function"},
+	)
+	lookupTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cloud_template_lookups_total",
+			Help: "The total number of template lookups by function and source i.e. api, cache or render",
+		},
+		[]string{"function", "source"},
+	)
 	templateDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name: "cloud_render_duration_seconds",
@@ -35,6 +49,8 @@ var (
 )
 
 func init() {
+	prometheus.MustRegister(lookupErrorTotal)
+	prometheus.MustRegister(lookupTotal)
 	prometheus.MustRegister(requestDuration)
 	prometheus.MustRegister(templateDuration)
 }
@@ -0,0 +1,80 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// Render is responsible for generating the stack template from the options using the engine
+// of the template
+func (p *provider) Render(ctx context.Context, options *models.CreateOptions) (string, error) {
+	if err := options.IsValid(); err != nil {
+		return "", err
+	}
+
+	return render(ctx, p.newTemplater().WithPartials(options.Partials), options)
+}
+
+// RenderWithStubs renders the template as the provider would, with the discovery lookups answered
+// from the stubs rather than the cloud apis; used to render templates offline
+func RenderWithStubs(ctx context.Context, config *models.ProviderConfig, options *models.CreateOptions, stubs *apiv1.DiscoveryStubs) (string, error) {
+	if err := options.IsValid(); err != nil {
+		return "", err
+	}
+	if config == nil {
+		config = &models.ProviderConfig{}
+	}
+
+	return render(ctx, NewTemplater(TemplaterClients{}, config).WithPartials(options.Partials).WithStubs(stubs), options)
+}
+
+// render is responsible for rendering the template content with the engine of the template
+func render(ctx context.Context, templater *Templater, options *models.CreateOptions) (string, error) {
+	template := options.Template
+
+	engine := template.Spec.GetEngine()
+	if len(options.Partials) > 0 && engine != apiv1.EngineGoTemplate {
+		return "", fmt.Errorf("fragments and base templates are not supported by the engine: %s", engine)
+	}
+	renderer, err := newRenderer(engine, templater)
+	if err != nil {
+		return "", err
+	}
+	data := models.NewRenderContext(options, templater.config)
+
+	return renderer.Render(ctx, data, template.Spec.Content, template.Spec.Format)
+}
+
+// Validate is responsible for checking the template content can be parsed by the engine
+func (p *provider) Validate(ctx context.Context, template *apiv1.CloudTemplate) error {
+	renderer, err := newRenderer(template.Spec.GetEngine(), p.newTemplater())
+	if err != nil {
+		return err
+	}
+
+	return renderer.Parse(template.Spec.Content)
+}
+
+// newTemplater returns a templater for a render, sharing the lookup cache if enabled
+func (p *provider) newTemplater() *Templater {
+	return NewTemplater(p.templaterClients(), p.config).WithCache(p.lookups)
+}
@@ -21,15 +21,27 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
 	"github.com/gambol99/resources/pkg/models"
 )
 
-// Status is responsible for getting the status
+// Status is responsible for getting the phase of the stack
 func (p *provider) Status(cx context.Context, name string, _ *models.GetOptions) (string, error) {
 	stack, _, err := p.getStack(cx, name)
 	if err != nil {
-		return models.StatusUnknown, err
+		return models.PhaseUnknown, err
 	}
 
-	return getStackStatus(aws.StringValue(stack.StackStatus)), nil
+	return getStackPhase(aws.StringValue(stack.StackStatus)), nil
+}
+
+// OperationStatus is responsible for checking the state of the operation on the stack, it never waits
+func (p *provider) OperationStatus(cx context.Context, name string, _ *apiv1.StackOperation) (*models.StackStatus, error) {
+	stack, _, err := p.getStack(cx, name)
+	if err != nil {
+		return nil, err
+	}
+	status := getStackStatus(stack)
+
+	return &status, nil
 }
@@ -25,136 +25,218 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/prometheus/client_golang/prometheus"
 
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
 	"github.com/gambol99/resources/pkg/models"
 )
 
+// TemplaterClients are the aws api clients used by the template lookups
+type TemplaterClients struct {
+	// Compute is the ec2 client
+	Compute ec2iface.EC2API
+	// DNS is the route53 client
+	DNS route53iface.Route53API
+	// Identity is the sts client
+	Identity stsiface.STSAPI
+	// Keys is the kms client
+	Keys kmsiface.KMSAPI
+}
+
 // Templater providers a cloudformation templater
 type Templater struct {
-	ctx    context.Context
-	client ec2iface.EC2API
-	config *models.ProviderConfig
+	ctx     context.Context
+	cache   map[string]interface{}
+	clients TemplaterClients
+	config  *models.ProviderConfig
+	// err is the first lookup error of the current render
+	err error
+	// partials are the fragments and base templates parsed before the content
+	partials []string
+	// shared is an optional cache of lookups across renders
+	shared *LookupCache
+	// stubs are the values of the lookups when testing a template
+	stubs *apiv1.DiscoveryStubs
 }
 
-// Render is responsibe for generating the template
-func (t *Templater) Render(c context.Context, values map[string]string, content string) (string, error) {
-	var err error
-
+// Render is responsibe for generating the template; parameters are escaped for the format of the
+// template and the result is checked against a render with the parameters output as placeholders
+func (t *Templater) Render(c context.Context, data *models.RenderContext, content, format string) (string, error) {
 	t.ctx = c
+
+	// @step; used to capture the time of a render
+	capture := prometheus.NewTimer(templateDuration)
+	defer capture.ObserveDuration()
+
+	generated, err := t.render(data.Values(), content, format)
+	if err != nil {
+		return "", err
+	}
+
+	// @step: render with the parameters output as placeholders and ensure the parameters have not altered
+	// the resources; the same values are used so any conditional resources are rendered alike
+	expected, err := t.renderExpected(data.Values(), content, format)
+	if err != nil {
+		return "", err
+	}
+	if err := checkTemplateStructure(generated, expected); err != nil {
+		return "", err
+	}
+
+	return generated, nil
+}
+
+// Parse checks the template content can be parsed with the template functions
+func (t *Templater) Parse(content string) error {
 	tm := template.New("main")
-	if _, err = tm.Funcs(t.templateFuncsMap(tm)).Parse(content); err != nil {
+	if err := t.parse(tm, content); err != nil {
+		return fmt.Errorf("unable to parse the template: %s", err)
+	}
+
+	return nil
+}
+
+// render is responsible for parsing, escaping and executing the template; a failed lookup
+// aborts the execution and is returned as a LookupError
+func (t *Templater) render(values map[string]interface{}, content, format string) (string, error) {
+	return t.execute(values, content, format, false)
+}
+
+// renderExpected renders the template with every escaped output replaced by a placeholder, giving the
+// structure of the template as the author wrote it
+func (t *Templater) renderExpected(values map[string]interface{}, content, format string) (string, error) {
+	return t.execute(values, content, format, true)
+}
+
+// execute is responsible for parsing, escaping and executing the template, optionally with the
+// escapers outputting placeholders rather than the values
+func (t *Templater) execute(values map[string]interface{}, content, format string, placeholders bool) (rendered string, err error) {
+	t.err = nil
+
+	tm := template.New("main")
+	if err = t.parse(tm, content); err != nil {
 		return "", err
 	}
 	tm.Option("missingkey=error")
 
+	// @step: inject the escapers into any actions outputting parameters
+	escapeTemplate(tm, format)
+	if placeholders {
+		tm.Funcs(placeholderFuncsMap())
+	}
+
 	// @step: render the actual template
-	writer := new(bytes.Buffer)
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("failed to parse template, error: %s", r)
+			rendered, err = "", fmt.Errorf("failed to render template, error: %v", r)
 		}
 	}()
 
-	// @step; used to capture the time of a render
-	capture := prometheus.NewTimer(templateDuration)
-	defer capture.ObserveDuration()
-
+	writer := new(bytes.Buffer)
 	if err = tm.ExecuteTemplate(writer, "main", values); err != nil {
+		if t.err != nil {
+			return "", t.err
+		}
+
 		return "", err
 	}
 
 	return writer.String(), nil
 }
 
-// NewTemplater creates and returns a templater
-func NewTemplater(client ec2iface.EC2API, config *models.ProviderConfig) *Templater {
-	return &Templater{
-		client: client,
-		config: config,
+// parse parses the partials followed by the content into the template; a later define block
+// replaces an earlier one and a body of only whitespace does not replace the main template
+func (t *Templater) parse(tm *template.Template, content string) error {
+	tm.Funcs(t.templateFuncsMap(tm))
+	for _, x := range t.partials {
+		if _, err := tm.Parse(x); err != nil {
+			return err
+		}
 	}
-}
+	_, err := tm.Parse(content)
 
-// Region returns the region we are in
-func (t *Templater) Region() string {
-	return t.config.Region
+	return err
 }
 
-// Subnets returns a list of subnets within the VPC
-func (t *Templater) Subnets() []models.Network {
-	resp, err := t.client.DescribeSubnetsWithContext(t.ctx, &ec2.DescribeSubnetsInput{
-		Filters: getClusterFilters(t.config.ClusterName),
-	})
-	if err != nil {
-		t.panic("failed to describe the subnets: %s", err)
+// NewTemplater creates and returns a templater, the lookups are cached for the life
+// of the templater i.e. a single render
+func NewTemplater(clients TemplaterClients, config *models.ProviderConfig) *Templater {
+	return &Templater{
+		cache:   make(map[string]interface{}, 0),
+		clients: clients,
+		config:  config,
 	}
+}
 
-	var list []models.Network
-	for _, x := range resp.Subnets {
-		tags := makeTags(x.Tags)
-		name := tags["Name"]
-		list = append(list, models.Network{
-			AvailabilityZone: aws.StringValue(x.AvailabilityZone),
-			CIDR:             aws.StringValue(x.CidrBlock),
-			Object: models.Object{
-				ID:   aws.StringValue(x.SubnetId),
-				Name: name,
-				Tags: tags,
-			},
-		})
-	}
+// WithPartials sets the fragments and base templates parsed before the content
+func (t *Templater) WithPartials(partials []string) *Templater {
+	t.partials = partials
 
-	return list
+	return t
 }
 
-// Network returns a list of networks
-func (t *Templater) Network() models.Network {
-	resp, err := t.client.DescribeVpcs(&ec2.DescribeVpcsInput{
-		Filters: getClusterFilters(t.config.ClusterName),
-	})
-	if err != nil {
-		t.panic("failed to describe the vpcs, error: %s", err)
+// WithStubs replaces the lookups with the stubbed values, a lookup which is not stubbed fails
+func (t *Templater) WithStubs(stubs *apiv1.DiscoveryStubs) *Templater {
+	if stubs == nil {
+		stubs = &apiv1.DiscoveryStubs{}
 	}
-	if len(resp.Vpcs) <= 0 {
-		t.panic("failed to find any vpcs")
-	}
-	if len(resp.Vpcs) > 1 {
-		t.panic("found more than one vpc")
-	}
-	tags := makeTags(resp.Vpcs[0].Tags)
-
-	return models.Network{
-		CIDR: aws.StringValue(resp.Vpcs[0].CidrBlock),
-		Object: models.Object{
-			ID:   aws.StringValue(resp.Vpcs[0].VpcId),
-			Name: tags["Name"],
-			Tags: tags,
-		},
+	t.stubs = stubs
+	if stubs.Region != "" {
+		config := *t.config
+		config.Region = stubs.Region
+		t.config = &config
 	}
+
+	return t
 }
 
-// NetworkID return the vpc id
-func (t *Templater) NetworkID() string {
-	return t.Network().ID
+// WithCache shares the lookups of the templater across renders via the cache
+func (t *Templater) WithCache(cache *LookupCache) *Templater {
+	t.shared = cache
+
+	return t
+}
+
+// Region returns the region we are in
+func (t *Templater) Region() string {
+	return t.config.Region
 }
 
 // templateFuncsMap returns a map if the template functions for this template
 func (t *Templater) templateFuncsMap(tm *template.Template) template.FuncMap {
-	//funcs := sprig.TxtFuncMap()
-	funcs := make(map[string]interface{}, 0)
+	funcs := libraryFuncsMap()
+	for k, v := range escapeFuncsMap() {
+		funcs[k] = v
+	}
+	funcs["accountID"] = t.AccountID
+	funcs["availabilityZones"] = t.AvailabilityZones
+	funcs["hostedZone"] = t.HostedZone
+	funcs[includeTemplate] = func(name string, data interface{}) (string, error) {
+		writer := new(bytes.Buffer)
+		if err := tm.ExecuteTemplate(writer, name, data); err != nil {
+			return "", err
+		}
+
+		return writer.String(), nil
+	}
+	funcs["kmsKeyArn"] = t.KMSKeyArn
+	funcs["latestAMI"] = t.LatestAMI
+	funcs["privateSubnets"] = t.PrivateSubnets
+	funcs["publicSubnets"] = t.PublicSubnets
 	funcs["region"] = t.Region
+	funcs["routeTables"] = t.RouteTables
+	funcs["securityGroups"] = t.SecurityGroups
+	funcs["subnets"] = t.Subnets
+	funcs["subnetsWithTag"] = t.SubnetsWithTag
 	funcs["vpc"] = t.Network
 	funcs["vpcid"] = t.NetworkID
-	funcs["subnets"] = t.Subnets
 
 	return funcs
 }
 
-// panic is responsible for throwing a panic
-func (t *Templater) panic(message string, opts ...interface{}) {
-	panic(fmt.Sprintf(message, opts...))
-}
-
 func makeTags(tags []*ec2.Tag) map[string]string {
 	list := make(map[string]string, 0)
 	for _, x := range tags {
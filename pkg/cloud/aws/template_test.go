@@ -0,0 +1,114 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func newTestRenderContext() *models.RenderContext {
+	return &models.RenderContext{
+		Cluster:   models.ClusterContext{Name: "dev", Region: "eu-west-2"},
+		Namespace: models.NamespaceContext{Name: "apps", Labels: map[string]string{"team": "platform"}},
+		Params:    map[string]string{"bucket": "test", "empty": ""},
+		Resource:  models.ObjectContext{Name: "bucket", Namespace: "apps", Labels: map[string]string{"app": "web"}},
+		Template:  models.ObjectContext{Name: "s3.bucket.v1"},
+	}
+}
+
+func TestRenderContext(t *testing.T) {
+	cases := []struct {
+		Content  string
+		Expected string
+	}{
+		{Content: `Name: {{ .bucket }}`, Expected: `Name: "test"`},
+		{Content: `Name: {{ .Params.bucket }}`, Expected: `Name: "test"`},
+		{Content: `Owner: "{{ .Resource.Namespace }}/{{ .Resource.Name }}"`, Expected: `Owner: "apps/bucket"`},
+		{Content: `Team: {{ index .Namespace.Labels "team" }}`, Expected: `Team: "platform"`},
+		{Content: `Cluster: {{ .Cluster.Name }}`, Expected: `Cluster: "dev"`},
+		{Content: `Template: "{{ .Template.Name }}"`, Expected: `Template: "s3.bucket.v1"`},
+		{Content: `Labels: {{ toJson .Resource.Labels }}`, Expected: `Labels: {"app":"web"}`},
+		{Content: `Hash: {{ sha256 "a" }}`, Expected: `Hash: ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb`},
+		{Content: `Value: {{ b64enc "a" }}`, Expected: `Value: YQ==`},
+	}
+	templater := NewTemplater(TemplaterClients{}, &models.ProviderConfig{})
+	for i, c := range cases {
+		rendered, err := templater.render(newTestRenderContext().Values(), c.Content, apiv1.FormatYAML)
+		require.NoError(t, err, "case %d", i)
+		assert.Equal(t, c.Expected, rendered, "case %d, content: %s", i, c.Content)
+	}
+}
+
+func TestRenderRequired(t *testing.T) {
+	templater := NewTemplater(TemplaterClients{}, &models.ProviderConfig{})
+	_, err := templater.render(newTestRenderContext().Values(), `Name: {{ required "a value is required" .Params.empty }}`, apiv1.FormatYAML)
+	assert.Error(t, err)
+}
+
+func TestRenderUnsafeFuncs(t *testing.T) {
+	templater := NewTemplater(TemplaterClients{}, &models.ProviderConfig{})
+	assert.Error(t, templater.Parse(`Key: {{ env "AWS_SECRET_ACCESS_KEY" }}`))
+}
+
+func TestRenderPartials(t *testing.T) {
+	partials := []string{
+		`{{- define "tags" }}
+- Key: Name
+  Value: {{ .bucket }}
+{{- end }}`,
+		`Name: {{ template "name" . }}
+Tags:
+{{- include "tags" . | indent 0 }}
+{{- define "name" }}base{{ end }}`,
+	}
+	templater := NewTemplater(TemplaterClients{}, &models.ProviderConfig{}).WithPartials(partials)
+	rendered, err := templater.render(newTestRenderContext().Values(), `{{- define "name" }}child{{ end }}`, apiv1.FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "Name: child\nTags:\n- Key: Name\n  Value: \"test\"", rendered)
+}
+
+func TestReferences(t *testing.T) {
+	content := `Name: {{ .bucket }}
+ACL: {{ .Params.acl }}
+Region: {{ index .Params "region" }}
+Owner: {{ .Resource.Name }}
+{{ range subnets }}- {{ .SubnetId }} {{ $.size }}{{ end }}
+{{ include "tags" . }}`
+	partials := []string{`{{ define "tags" }}Team: {{ .team }}{{ end }}`}
+
+	templater := NewTemplater(TemplaterClients{}, &models.ProviderConfig{}).WithPartials(partials)
+	references, err := templater.References(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acl", "bucket", "region", "size", "team"}, references)
+}
+
+func TestTemplateOutputs(t *testing.T) {
+	outputs, err := getTemplateOutputs("Outputs:\n  Bucket:\n    Value: a\n  Arn:\n    Value: b\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Arn", "Bucket"}, outputs)
+
+	outputs, err = getTemplateOutputs(`{"Resources": {}}`)
+	require.NoError(t, err)
+	assert.Empty(t, outputs)
+	assert.NotNil(t, outputs)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// testResourceName is the name of the resource the test cases are rendered for
+	testResourceName = "test"
+	// testResourceNamespace is the namespace of the resource the test cases are rendered for
+	testResourceNamespace = "default"
+)
+
+// Test runs the test cases of the template
+func (p *provider) Test(ctx context.Context, template *apiv1.CloudTemplate, partials []string) ([]models.TemplateTestOutcome, error) {
+	return RunTemplateTests(ctx, p.config, template, partials), nil
+}
+
+// RunTemplateTests renders each of the test cases of the template with the discovery functions
+// stubbed, comparing the result with the expected template and assertions; no cloud apis are called
+func RunTemplateTests(ctx context.Context, config *models.ProviderConfig, template *apiv1.CloudTemplate, partials []string) []models.TemplateTestOutcome {
+	var outcomes []models.TemplateTestOutcome
+
+	for _, x := range template.Spec.Tests {
+		outcome := models.TemplateTestOutcome{Name: x.Name}
+
+		rendered, err := renderTest(ctx, config, template, partials, x)
+		switch {
+		case x.ExpectError != "" && err == nil:
+			outcome.Failures = append(outcome.Failures, fmt.Sprintf("expected the render to fail with: %s", x.ExpectError))
+		case x.ExpectError != "":
+			if !strings.Contains(err.Error(), x.ExpectError) {
+				outcome.Failures = append(outcome.Failures, fmt.Sprintf("expected the render to fail with: %s, error: %s", x.ExpectError, err))
+			}
+		case err != nil:
+			outcome.Failures = append(outcome.Failures, fmt.Sprintf("render failed: %s", err))
+		default:
+			outcome.Rendered = rendered
+			if x.Expected != "" {
+				if diff := models.DiffLines(x.Expected, rendered); diff != "" {
+					outcome.Failures = append(outcome.Failures, fmt.Sprintf("rendered template differs from expected:\n%s", diff))
+				}
+			}
+			outcome.Failures = append(outcome.Failures, checkAssertions(rendered, x.Assertions)...)
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes
+}
+
+// renderTest renders the template with the parameters and stubbed discovery values of the test case
+func renderTest(ctx context.Context, config *models.ProviderConfig, template *apiv1.CloudTemplate, partials []string, test apiv1.TemplateTest) (string, error) {
+	// @step: the defaults of the template are overridden by the parameters of the test
+	params := make(map[string]string, 0)
+	for _, x := range template.Spec.Parameters {
+		if x.Value != nil {
+			params[x.Name] = *x.Value
+			continue
+		}
+		if _, found := test.Parameters[x.Name]; !found {
+			return "", fmt.Errorf("parameter: %s is required", x.Name)
+		}
+	}
+	for k, v := range test.Parameters {
+		params[k] = v
+	}
+
+	return RenderWithStubs(ctx, config, &models.CreateOptions{
+		Context:  params,
+		Partials: partials,
+		Resource: &apiv1.CloudResource{
+			ObjectMeta: metav1.ObjectMeta{Name: testResourceName, Namespace: testResourceNamespace},
+		},
+		Template: template,
+	}, test.Discovery)
+}
+
+// checkAssertions evaluates the jsonpath assertions against the rendered template
+func checkAssertions(rendered string, assertions []apiv1.TemplateAssertion) []string {
+	if len(assertions) <= 0 {
+		return nil
+	}
+	encoded, err := yaml.YAMLToJSON([]byte(rendered))
+	if err != nil {
+		return []string{fmt.Sprintf("unable to parse the rendered template: %s", err)}
+	}
+	var document interface{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return []string{fmt.Sprintf("unable to decode the rendered template: %s", err)}
+	}
+
+	var failures []string
+	for _, x := range assertions {
+		value, err := getJSONPathValue(document, x.Path)
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("path: %s, %s", x.Path, err))
+		case x.Value != nil && value != *x.Value:
+			failures = append(failures, fmt.Sprintf("path: %s, expected: %q, got: %q", x.Path, *x.Value, value))
+		}
+	}
+
+	return failures
+}
+
+// getJSONPathValue returns the value at the jsonpath in the document, an error if it does not exist
+func getJSONPathValue(document interface{}, path string) (string, error) {
+	parser := jsonpath.New("assertion")
+	if err := parser.Parse(path); err != nil {
+		return "", fmt.Errorf("invalid jsonpath: %s", err)
+	}
+	writer := new(bytes.Buffer)
+	if err := parser.Execute(writer, document); err != nil {
+		return "", err
+	}
+
+	return writer.String(), nil
+}
+
+// getStubbedLookup returns the stubbed value of the lookup in the type returned by the lookup; the
+// lookups taking arguments are stubbed on the function and the arguments
+func getStubbedLookup(stubs *apiv1.DiscoveryStubs, function, key string) (interface{}, error) {
+	switch function {
+	case "accountID":
+		if stubs.AccountID != "" {
+			return stubs.AccountID, nil
+		}
+	case "availabilityZones":
+		if stubs.AvailabilityZones != nil {
+			list := append([]string{}, stubs.AvailabilityZones...)
+			sort.Strings(list)
+			return list, nil
+		}
+	case "subnets":
+		if stubs.Subnets != nil {
+			var list []models.Network
+			for _, x := range stubs.Subnets {
+				list = append(list, toStubNetwork(x))
+			}
+			return list, nil
+		}
+	case "vpc":
+		if stubs.VPC != nil {
+			return toStubNetwork(*stubs.VPC), nil
+		}
+	case "hostedZone":
+		domain := strings.TrimSuffix(key, ".")
+		if v, found := stubs.Lookups[function+"/"+domain]; found {
+			return models.HostedZone{ID: v, Name: domain}, nil
+		}
+	case "kmsKeyArn", "latestAMI":
+		if v, found := stubs.Lookups[function+"/"+key]; found {
+			return v, nil
+		}
+	}
+	if key != "" {
+		return nil, fmt.Errorf("no stubbed value for the lookup: %s/%s", function, key)
+	}
+
+	return nil, fmt.Errorf("no stubbed value for the lookup: %s", function)
+}
+
+// toStubNetwork converts the stubbed network
+func toStubNetwork(stub apiv1.StubNetwork) models.Network {
+	return models.Network{
+		AvailabilityZone: stub.AvailabilityZone,
+		CIDR:             stub.CIDR,
+		Object: models.Object{
+			ID:   stub.ID,
+			Name: stub.Name,
+			Tags: stub.Tags,
+		},
+	}
+}
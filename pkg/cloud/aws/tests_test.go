@@ -0,0 +1,94 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func TestRunTemplateTests(t *testing.T) {
+	bucket := "test-bucket"
+	wrong := "wrong"
+	template := &apiv1.CloudTemplate{
+		Spec: apiv1.TemplateSpec{
+			Format:     apiv1.FormatYAML,
+			Parameters: []apiv1.Parameter{{Name: "name"}},
+			Content:    "Bucket: {{ .name }}\nAccount: {{ accountID }}\n",
+			Tests: []apiv1.TemplateTest{
+				{
+					Name:       "golden",
+					Parameters: map[string]string{"name": bucket},
+					Discovery:  &apiv1.DiscoveryStubs{AccountID: "123456789012"},
+					Expected:   "Bucket: \"test-bucket\"\nAccount: 123456789012\n",
+					Assertions: []apiv1.TemplateAssertion{{Path: "{.Bucket}", Value: &bucket}},
+				},
+				{
+					Name:       "assertion",
+					Parameters: map[string]string{"name": bucket},
+					Discovery:  &apiv1.DiscoveryStubs{AccountID: "123456789012"},
+					Assertions: []apiv1.TemplateAssertion{{Path: "{.Bucket}", Value: &wrong}, {Path: "{.Missing}"}},
+				},
+				{Name: "required", ExpectError: "parameter: name is required"},
+			},
+		},
+	}
+
+	outcomes := RunTemplateTests(context.Background(), &models.ProviderConfig{}, template, nil)
+	require.Len(t, outcomes, 3)
+	assert.True(t, outcomes[0].Passed(), "failures: %v", outcomes[0].Failures)
+	assert.False(t, outcomes[1].Passed())
+	assert.Len(t, outcomes[1].Failures, 2)
+	assert.True(t, outcomes[2].Passed(), "failures: %v", outcomes[2].Failures)
+}
+
+func TestStubbedLookupArguments(t *testing.T) {
+	stubs := &apiv1.DiscoveryStubs{
+		Lookups: map[string]string{
+			"hostedZone/example.com":                "Z1",
+			"kmsKeyArn/alias/secrets":               "arn:aws:kms:eu-west-2:123456789012:key/1",
+			"latestAMI/amzn2-ami-hvm-*/amazon":      "ami-1",
+			"latestAMI/amzn2-ami-hvm-*/self,amazon": "ami-2",
+		},
+	}
+	templater := NewTemplater(TemplaterClients{}, &models.ProviderConfig{}).WithStubs(stubs)
+
+	zone, err := templater.HostedZone("example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, models.HostedZone{ID: "Z1", Name: "example.com"}, zone)
+
+	arn, err := templater.KMSKeyArn("secrets")
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:kms:eu-west-2:123456789012:key/1", arn)
+
+	// @check the stubs are keyed on the arguments of the lookup
+	ami, err := templater.LatestAMI("amzn2-ami-hvm-*", "amazon")
+	require.NoError(t, err)
+	assert.Equal(t, "ami-1", ami)
+	ami, err = templater.LatestAMI("amzn2-ami-hvm-*")
+	require.NoError(t, err)
+	assert.Equal(t, "ami-2", ami)
+
+	_, err = templater.LatestAMI("ubuntu-*", "amazon")
+	assert.Error(t, err)
+}
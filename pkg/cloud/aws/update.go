@@ -43,6 +43,9 @@ func (p *provider) UpdateTags(ctx context.Context, name string, tags map[string]
 		Tags:                makeStackTags(tags),
 		UsePreviousTemplate: aws.Bool(true),
 	})
+	if isNoUpdatesError(err) {
+		return nil
+	}
 
-	return err
+	return classifyError(err)
 }
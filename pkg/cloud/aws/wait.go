@@ -28,20 +28,27 @@ var (
 	defaultWaitCheckInterval = time.Second * 5
 )
 
-// Wait is responsible for waiting for a stack to complete or fail
+// Wait is responsible for waiting for a stack to complete or fail, returning the phase
 func (p *provider) Wait(ctx context.Context, name string, options *models.WaitOptions) (string, error) {
 	interval := defaultWaitCheckInterval
 	if options != nil && options.CheckInterval > 0 {
 		interval = options.CheckInterval
 	}
 
+	if options != nil && options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
 	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
 	// @check the stack exists
 	if found, err := p.hasStack(ctx, name); err != nil {
-		return models.StatusUnknown, err
+		return models.PhaseUnknown, err
 	} else if !found {
-		return models.StatusUnknown, models.ErrStackNotFound
+		return models.PhaseUnknown, models.ErrStackNotFound
 	}
 
 	// @step: wait for check interval or signal to end
@@ -49,12 +56,15 @@ func (p *provider) Wait(ctx context.Context, name string, options *models.WaitOp
 	for {
 		select {
 		case <-ctx.Done():
-			return models.StatusUnknown, models.ErrOperationAborted
+			if ctx.Err() == context.DeadlineExceeded {
+				return models.PhaseUnknown, models.ErrOperationTimeout
+			}
+			return models.PhaseUnknown, models.ErrOperationAborted
 		case <-ticker.C:
 
 			status, err := p.Status(ctx, name, nil)
 			if err != nil {
-				return models.StatusUnknown, err
+				return models.PhaseUnknown, err
 			}
 
 			log.WithFields(log.Fields{
@@ -63,7 +73,7 @@ func (p *provider) Wait(ctx context.Context, name string, options *models.WaitOp
 				"status":    status,
 			}).Debug("checking the status stack")
 
-			if status == models.StatusInProgress {
+			if models.IsPhaseInProgress(status) {
 				continue
 			}
 
@@ -23,6 +23,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
 	"github.com/gambol99/resources/pkg/models"
 )
 
@@ -45,6 +46,16 @@ func (p *provider) Credentials(context.Context, string) ([]models.Credential, er
 	return []models.Credential{}, nil
 }
 
+// Cancel is responsible for cancelling the operation on the stack, operations complete immediately so
+// there is never anything to cancel
+func (p *provider) Cancel(ctx context.Context, name string) error {
+	if _, err := p.getStack(ctx, name); err != nil {
+		return err
+	}
+
+	return models.ErrNotCancellable
+}
+
 // Create is responsible for creating or updating a stack
 func (p *provider) Create(ctx context.Context, name string, options *models.CreateOptions) error {
 	log.WithFields(log.Fields{
@@ -56,19 +67,25 @@ func (p *provider) Create(ctx context.Context, name string, options *models.Crea
 	}).Info("creating a new stack")
 
 	resource := options.Resource
+	content := options.Content
+	if content == "" {
+		content = options.Template.Spec.Content
+	}
 
 	stack := &models.Stack{
 		Created:   time.Now(),
 		Name:      name,
 		Namespace: resource.Namespace,
 		Spec: models.StackSpec{
+			Content:   content,
 			Name:      resource.Name,
 			Retention: time.Duration(time.Hour * 24),
 			Tags:      options.Tags,
 			Template:  options.Template.Name,
 		},
 		Status: models.StackStatus{
-			Status: models.StatusDone,
+			LastTransitionTime: time.Now(),
+			Phase:              models.PhaseReady,
 		},
 	}
 
@@ -87,6 +104,49 @@ func (p *provider) Logs(ctx context.Context, name string, options *models.GetOpt
 	return "", err
 }
 
+// Events returns no events as nothing is provisioned
+func (p *provider) Events(ctx context.Context, name string, options *models.GetOptions) ([]models.StackEvent, error) {
+	_, err := p.getStack(ctx, name)
+
+	return nil, err
+}
+
+// OperationStatus returns the status of the stack, operations complete immediately
+func (p *provider) OperationStatus(ctx context.Context, name string, operation *apiv1.StackOperation) (*models.StackStatus, error) {
+	stack, err := p.getStack(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	status := stack.Status
+
+	return &status, nil
+}
+
+// Render returns the template content as is
+func (p *provider) Render(ctx context.Context, options *models.CreateOptions) (string, error) {
+	if err := options.IsValid(); err != nil {
+		return "", err
+	}
+
+	return options.Template.Spec.Content, nil
+}
+
+// Validate accepts any template content
+func (p *provider) Validate(ctx context.Context, template *apiv1.CloudTemplate) error {
+	return nil
+}
+
+// Analyze returns nothing as the content is not interpreted
+func (p *provider) Analyze(ctx context.Context, template *apiv1.CloudTemplate, partials []string) (*models.TemplateAnalysis, error) {
+	return &models.TemplateAnalysis{}, nil
+}
+
+// Test has no test cases to run as the content is not interpreted
+func (p *provider) Test(ctx context.Context, template *apiv1.CloudTemplate, partials []string) ([]models.TemplateTestOutcome, error) {
+	return nil, nil
+}
+
 // Delete is responsible for removing the stack
 func (p *provider) Delete(ctx context.Context, name string, options *models.DeleteOptions) error {
 	log.WithFields(log.Fields{
@@ -104,6 +164,22 @@ func (p *provider) Delete(ctx context.Context, name string, options *models.Dele
 	return nil
 }
 
+// Diff compares the stack with the template rendered from the options
+func (p *provider) Diff(ctx context.Context, name string, options *models.CreateOptions) (*apiv1.StackDiff, error) {
+	stack, err := p.getStack(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	rendered := options.Content
+	if rendered == "" {
+		if rendered, err = p.Render(ctx, options); err != nil {
+			return nil, err
+		}
+	}
+
+	return models.DiffStack(stack, rendered, options.Tags)
+}
+
 // Exists is responsible for checking is stack already exists
 func (p *provider) Exists(ctx context.Context, name string) (*models.Stack, bool, error) {
 	stack, err := p.getStack(ctx, name)
@@ -135,14 +211,14 @@ func (p *provider) List(context.Context, *models.ListOptions) ([]*models.Stack,
 	return list, nil
 }
 
-// Status is responsible for getting the status
+// Status is responsible for getting the phase of the stack
 func (p *provider) Status(ctx context.Context, name string, options *models.GetOptions) (string, error) {
 	stack, err := p.getStack(ctx, name)
 	if err != nil {
-		return models.StatusUnknown, err
+		return models.PhaseUnknown, err
 	}
 
-	return stack.Status.Status, nil
+	return stack.Status.Phase, nil
 }
 
 // UpdateTags is responsible for updating just the tags of a stack
@@ -163,7 +239,7 @@ func (p *provider) UpdateTags(ctx context.Context, name string, tags map[string]
 
 // Wait is responsible for waiting for a stack to complete or fail
 func (p *provider) Wait(context.Context, string, *models.WaitOptions) (string, error) {
-	return models.StatusDone, nil
+	return models.PhaseReady, nil
 }
 
 func (p *provider) getStack(ctx context.Context, name string) (*models.Stack, error) {
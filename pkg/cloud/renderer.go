@@ -0,0 +1,31 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// Renderer is a template engine used to generate the stack template from the content
+type Renderer interface {
+	// Parse checks the content can be parsed by the engine
+	Parse(content string) error
+	// Render generates the stack template in the format from the content and render context
+	Render(ctx context.Context, data *models.RenderContext, content, format string) (string, error)
+}
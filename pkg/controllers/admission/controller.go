@@ -0,0 +1,185 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	admission "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gambol99/resources/pkg/controllers/api"
+)
+
+const (
+	// MutatePath is the url path for the mutating webhook
+	MutatePath = "/mutate"
+	// ValidatePath is the url path for the validating webhook
+	ValidatePath = "/validate"
+)
+
+// reviewFunc handles an admission request and produces the response
+type reviewFunc func(context.Context, *admission.AdmissionRequest) (*admission.AdmissionResponse, error)
+
+// the controller serves the admission webhooks; unlike the other controllers every
+// instance serves requests regardless of leadership
+type controller struct {
+	// config are the controller config
+	config *api.Config
+	// options are the controller options
+	options *api.Options
+	// waitgroup is a wait group for the inflight requests
+	waitgroup *sync.WaitGroup
+}
+
+// New returns a new admission controller
+func New(options *api.Options) (api.Controller, error) {
+	if options.Config.TLSCert == "" || options.Config.TLSKey == "" {
+		return nil, fmt.Errorf("admission webhooks require a tls certificate and private key")
+	}
+
+	return &controller{
+		config:    options.Config,
+		options:   options,
+		waitgroup: &sync.WaitGroup{},
+	}, nil
+}
+
+// Run is responsible for starting the webhook server
+func (c *controller) Run(ctx context.Context) error {
+	certificate, err := tls.LoadX509KeyPair(c.config.TLSCert, c.config.TLSKey)
+	if err != nil {
+		return fmt.Errorf("unable to load the tls certificate: %s", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(MutatePath, c.handle(c.mutate))
+	mux.HandleFunc(ValidatePath, c.handle(c.validate))
+
+	server := &http.Server{
+		Addr:    c.config.AdmissionListen,
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+
+	log.WithFields(log.Fields{
+		"listen": c.config.AdmissionListen,
+	}).Info("starting the admission webhook server")
+
+	errorCh := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			errorCh <- err
+		}
+	}()
+
+	// @step: wait for a signal to stop
+	select {
+	case <-ctx.Done():
+	case err := <-errorCh:
+		return fmt.Errorf("admission webhook server failed: %s", err)
+	}
+	log.WithFields(log.Fields{"controller": c.Name()}).Info("shutting down the controller")
+
+	shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return server.Shutdown(shutdown)
+}
+
+// handle is responsible for decoding the admission review, calling the handler and encoding the response
+func (c *controller) handle(fn reviewFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		c.waitgroup.Add(1)
+		defer c.waitgroup.Done()
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, "unable to read the request body", http.StatusBadRequest)
+			return
+		}
+		review := &admission.AdmissionReview{}
+		if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+			http.Error(w, "invalid admission review", http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), 10*time.Second)
+		defer cancel()
+
+		response, err := fn(ctx, review.Request)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":     err.Error(),
+				"kind":      review.Request.Kind.Kind,
+				"name":      review.Request.Name,
+				"namespace": review.Request.Namespace,
+			}).Error("failed to handle the admission request")
+
+			response = deny(err.Error())
+		}
+		response.UID = review.Request.UID
+		admissionTotal.WithLabelValues(review.Request.Kind.Kind, fmt.Sprintf("%t", response.Allowed)).Inc()
+
+		encoded, err := json.Marshal(&admission.AdmissionReview{Response: response})
+		if err != nil {
+			http.Error(w, "unable to encode the admission review", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(encoded)
+	}
+}
+
+// allow returns a response permitting the request
+func allow() *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{Allowed: true}
+}
+
+// deny returns a response rejecting the request
+func deny(message string) *admission.AdmissionResponse {
+	return &admission.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Code:    http.StatusForbidden,
+			Message: message,
+			Reason:  metav1.StatusReasonForbidden,
+			Status:  metav1.StatusFailure,
+		},
+	}
+}
+
+// Name returns the name of the controller
+func (c *controller) Name() string {
+	return "admission"
+}
+
+// Wait returns the task group stopped
+func (c *controller) Wait() {
+	c.waitgroup.Wait()
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	admission "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// patchOperation is a json patch operation
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// validate is responsible for rejecting invalid resources and templates
+func (c *controller) validate(ctx context.Context, req *admission.AdmissionRequest) (*admission.AdmissionResponse, error) {
+	if req.Operation == admission.Delete {
+		return allow(), nil
+	}
+
+	log.WithFields(log.Fields{
+		"kind":      req.Kind.Kind,
+		"name":      req.Name,
+		"namespace": req.Namespace,
+		"operation": req.Operation,
+	}).Debug("validating the admission request")
+
+	switch req.Kind.Kind {
+	case "CloudResource":
+		resource := &apiv1.CloudResource{}
+		if err := json.Unmarshal(req.Object.Raw, resource); err != nil {
+			return nil, fmt.Errorf("unable to decode the cloud resource: %s", err)
+		}
+		if req.Operation == admission.Update {
+			previous := &apiv1.CloudResource{}
+			if err := json.Unmarshal(req.OldObject.Raw, previous); err != nil {
+				return nil, fmt.Errorf("unable to decode the previous cloud resource: %s", err)
+			}
+			if !isValidationRequired(resource.DeletionTimestamp, previous.Spec, resource.Spec) {
+				return allow(), nil
+			}
+		}
+
+		return c.validateCloudResource(ctx, resource)
+	case "CloudTemplate":
+		template := &apiv1.CloudTemplate{}
+		if err := json.Unmarshal(req.Object.Raw, template); err != nil {
+			return nil, fmt.Errorf("unable to decode the cloud template: %s", err)
+		}
+		if req.Operation == admission.Update {
+			previous := &apiv1.CloudTemplate{}
+			if err := json.Unmarshal(req.OldObject.Raw, previous); err != nil {
+				return nil, fmt.Errorf("unable to decode the previous cloud template: %s", err)
+			}
+			if !isValidationRequired(template.DeletionTimestamp, previous.Spec, template.Spec) {
+				return allow(), nil
+			}
+		}
+
+		return c.validateCloudTemplate(ctx, template)
+	}
+
+	return allow(), nil
+}
+
+// isValidationRequired checks if an update needs validating; the updates made by the controllers, i.e.
+// the status and the finalizers, leave the spec unchanged and must be permitted even when the object
+// is invalid, as must any update to an object being deleted, else the object could never be removed
+func isValidationRequired(deletion *metav1.Time, previous, spec interface{}) bool {
+	if deletion != nil {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(previous, spec)
+}
+
+// validateCloudResource checks the resource and the template it references
+func (c *controller) validateCloudResource(ctx context.Context, resource *apiv1.CloudResource) (*admission.AdmissionResponse, error) {
+	if errs := resource.IsValid(); len(errs) > 0 {
+		return deny(utils.GetErrors(errs).Error()), nil
+	}
+
+	// @check the template exists
+	template, err := utils.FindCloudTemplate(c.options.ResourceClient, resource.Spec.TemplateName)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return deny(fmt.Sprintf("cloud template: %s does not exist", resource.Spec.TemplateName)), nil
+		}
+		return nil, fmt.Errorf("unable to retrieve cloud template: %s, error: %s", resource.Spec.TemplateName, err)
+	}
+	resolved, err := utils.ResolveCloudTemplate(c.options.ResourceClient, c.options.Content, template)
+	if err != nil {
+		return deny(fmt.Sprintf("unable to resolve cloud template: %s, error: %s", resource.Spec.TemplateName, err)), nil
+	}
+	template = resolved.Template
+
+	// @check all the required parameters have been provided
+	if missing := resource.GetMissingParameters(template); len(missing) > 0 {
+		return deny(fmt.Sprintf("required parameters missing: %s", strings.Join(missing, ","))), nil
+	}
+
+	// @check the secret mappings are usable with the template
+	if reasons := models.CheckSecretMappings(resource, template); len(reasons) > 0 {
+		return deny(fmt.Sprintf("invalid secret mappings: %s", strings.Join(reasons, "; "))), nil
+	}
+
+	// @note: the admission api pinned here cannot return warnings, the deprecation of the template is
+	// raised as an event on the resource by the resources controller instead
+	return allow(), nil
+}
+
+// validateCloudTemplate checks the template specification and content
+func (c *controller) validateCloudTemplate(ctx context.Context, template *apiv1.CloudTemplate) (*admission.AdmissionResponse, error) {
+	if errs := template.IsValid(); len(errs) > 0 {
+		return deny(utils.GetErrors(errs).Error()), nil
+	}
+	loaded, err := c.options.Content.Load(template)
+	if err != nil {
+		return deny(fmt.Sprintf("unable to load the template content: %s", err)), nil
+	}
+	if err := c.options.Cloud.Validate(ctx, loaded); err != nil {
+		return deny(err.Error()), nil
+	}
+	// @check the inheritance of the template does not form a cycle
+	if _, err := utils.ResolveCloudTemplate(c.options.ResourceClient, c.options.Content, template); models.IsTemplateCycle(err) {
+		return deny(err.Error()), nil
+	}
+
+	return allow(), nil
+}
+
+// mutate is responsible for defaulting the resource from the template
+func (c *controller) mutate(ctx context.Context, req *admission.AdmissionRequest) (*admission.AdmissionResponse, error) {
+	if req.Kind.Kind != "CloudResource" || req.Operation == admission.Delete {
+		return allow(), nil
+	}
+
+	resource := &apiv1.CloudResource{}
+	if err := json.Unmarshal(req.Object.Raw, resource); err != nil {
+		return nil, fmt.Errorf("unable to decode the cloud resource: %s", err)
+	}
+	if resource.Spec.TemplateName == "" {
+		return allow(), nil
+	}
+
+	// @step: a missing template is left to the validating webhook to reject
+	template, err := utils.FindCloudTemplate(c.options.ResourceClient, resource.Spec.TemplateName)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return allow(), nil
+		}
+		return nil, fmt.Errorf("unable to retrieve cloud template: %s, error: %s", resource.Spec.TemplateName, err)
+	}
+	// @step: the defaults may be inherited from the base templates
+	if resolved, err := utils.ResolveCloudTemplate(c.options.ResourceClient, c.options.Content, template); err == nil {
+		template = resolved.Template
+	}
+
+	patch := getDefaultsPatch(resource, template)
+	if len(patch) <= 0 {
+		return allow(), nil
+	}
+	encoded, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"name":      resource.Name,
+		"namespace": resource.Namespace,
+		"template":  template.Name,
+	}).Debug("defaulting the cloud resource from the template")
+
+	response := allow()
+	patchType := admission.PatchTypeJSONPatch
+	response.Patch = encoded
+	response.PatchType = &patchType
+
+	return response, nil
+}
+
+// getDefaultsPatch returns the json patch defaulting the resource from the template
+func getDefaultsPatch(resource *apiv1.CloudResource, template *apiv1.CloudTemplate) []patchOperation {
+	defaulted := resource.DeepCopy()
+	if !defaulted.ApplyDefaults(template) {
+		return nil
+	}
+
+	var patch []patchOperation
+	if resource.Spec.Retention == nil && defaulted.Spec.Retention != nil {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/retention", Value: defaulted.Spec.Retention})
+	}
+	if resource.Spec.DeleteOn == nil && defaulted.Spec.DeleteOn != nil {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/deleteOn", Value: defaulted.Spec.DeleteOn})
+	}
+
+	return patch
+}
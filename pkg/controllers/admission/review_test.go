@@ -0,0 +1,85 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admission "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func newUpdateRequest(t *testing.T, kind string, previous, current interface{}) *admission.AdmissionRequest {
+	old, err := json.Marshal(previous)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(current)
+	require.NoError(t, err)
+
+	return &admission.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: "cloud.appvia.io", Version: "v1", Kind: kind},
+		Operation: admission.Update,
+		Object:    runtime.RawExtension{Raw: encoded},
+		OldObject: runtime.RawExtension{Raw: old},
+	}
+}
+
+func TestValidateUnchangedSpec(t *testing.T) {
+	c := &controller{options: &api.Options{}}
+
+	// @check the status of an invalid template can be written by the controller
+	template := &apiv1.CloudTemplate{ObjectMeta: metav1.ObjectMeta{Name: "bucket"}}
+	updated := template.DeepCopy()
+	updated.Status.Status = models.StatusTemplateInvalid
+	resp, err := c.validate(context.Background(), newUpdateRequest(t, "CloudTemplate", template, updated))
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+
+	// @check a change to the spec is still validated
+	updated.Spec.Content = "Resources: {}"
+	resp, err = c.validate(context.Background(), newUpdateRequest(t, "CloudTemplate", template, updated))
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+}
+
+func TestValidateDeletingObject(t *testing.T) {
+	c := &controller{options: &api.Options{}}
+
+	// @check the finalizer can be removed from a resource being deleted
+	now := metav1.Now()
+	resource := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "bucket",
+			Namespace:         "test",
+			DeletionTimestamp: &now,
+			Finalizers:        []string{models.TemplateFinalizer},
+		},
+	}
+	updated := resource.DeepCopy()
+	updated.Finalizers = nil
+	resp, err := c.validate(context.Background(), newUpdateRequest(t, "CloudResource", resource, updated))
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+}
@@ -25,30 +25,56 @@ import (
 
 	"github.com/gambol99/resources/pkg/client/clientset/versioned"
 	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/policy"
+	"github.com/gambol99/resources/pkg/utils"
 )
 
 // Config defines the configuraton for the controller
 type Config struct {
+	// AdmissionListen is the interface the admission webhooks listen on
+	AdmissionListen string
 	// CloudProvider is the actual provider i.e. aws
 	CloudProvider string
 	// ClusterName is the name of the cluster
 	ClusterName string
+	// EnableAdmission enables the admission webhook server
+	EnableAdmission bool
 	// EnableMetrics enables the metrics endpoint
 	EnableMetrics bool
 	// ElectionNamespace is the namespace for the endpoint election
 	ElectionNamespace string
 	// KubeConfig is an optional path to a kubeconfig file
 	KubeConfig string
+	// LookupTTL is the duration template lookups are cached across renders
+	LookupTTL time.Duration
+	// MaxRetries is the max attempts to retry a failed reconcile which may succeed on retry
+	MaxRetries int
 	// MetricsListen is the interface we should expose the metrics on
 	MetricsListen string
 	// Name is the name of the controller
 	Name string
+	// PolicyDir is an optional directory of rego policies to load
+	PolicyDir string
+	// PolicyNamespace is the namespace to watch for rego policy configmaps
+	PolicyNamespace string
 	// ResyncDuration is the default resync time duration for the controller
 	ResyncDuration time.Duration
 	// StackTimeout is the timeout for a stack to complete
 	StackTimeout time.Duration
+	// StackTimeoutAction is the default action on an operation exceeding the timeout
+	StackTimeoutAction string
+	// SupersedePolicy is the default policy on a resource changing while an operation is in progress
+	SupersedePolicy string
+	// TemplateBundleDir is an optional directory of templates synchronized into the cloud templates
+	TemplateBundleDir string
+	// TemplatesDir is an optional directory of template content referenced by the templates
+	TemplatesDir string
 	// Threadness is the number of controller threads to run
 	Threadness int
+	// TLSCert is the path to the certificate used by the admission webhooks
+	TLSCert string
+	// TLSKey is the path to the private key used by the admission webhooks
+	TLSKey string
 	// Verbose indicates verbose logging
 	Verbose bool
 }
@@ -69,8 +95,12 @@ type Options struct {
 	Cloud models.CloudProvider
 	// Config is the configuraton for the controller
 	Config *Config
+	// Content loads the template content from configmaps and the templates directory
+	Content *utils.ContentLoader
 	// Election checks for leadership
 	Election Leadership
+	// Policies is the rego policy engine
+	Policies *policy.RegoEngine
 	// Record is a event recorder
 	Record record.EventRecorder
 	// ResourceClient is the client for resources
@@ -0,0 +1,162 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+const (
+	// metadataFile is the name of the metadata file of a template in the bundle
+	metadataFile = "metadata.yaml"
+	// contentPrefix is the prefix of the content file of a template in the bundle i.e. template.yaml
+	contentPrefix = "template."
+)
+
+// metadata is the metadata file of a template in the bundle; the specification of the template
+// i.e. parameters, secrets, retention and format are inline
+type metadata struct {
+	// Annotations are added to the cloud template
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Labels are added to the cloud template
+	Labels map[string]string `json:"labels,omitempty"`
+	// TemplateSpec is the specification of the template, the content is read from the content file
+	apiv1.TemplateSpec `json:",inline"`
+}
+
+// readBundle reads the templates from the bundle directory; each template is a directory named after
+// the template, holding a metadata.yaml and a single template.<ext> content file
+func readBundle(dir string) ([]*apiv1.CloudTemplate, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*apiv1.CloudTemplate
+	for _, x := range entries {
+		if !x.IsDir() || strings.HasPrefix(x.Name(), ".") {
+			continue
+		}
+		template, err := readTemplate(filepath.Join(dir, x.Name()), x.Name())
+		if err != nil {
+			return nil, fmt.Errorf("template: %s, error: %s", x.Name(), err)
+		}
+		list = append(list, template)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list, nil
+}
+
+// readTemplate reads the template from the directory
+func readTemplate(dir, name string) (*apiv1.CloudTemplate, error) {
+	encoded, err := ioutil.ReadFile(filepath.Join(dir, metadataFile))
+	if err != nil {
+		return nil, err
+	}
+	meta := &metadata{}
+	if err := yaml.Unmarshal(encoded, meta); err != nil {
+		return nil, fmt.Errorf("unable to decode the metadata: %s", err)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, contentPrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		if meta.Extends == "" && meta.ContentFrom == nil {
+			return nil, fmt.Errorf("no %s* content file found", contentPrefix)
+		}
+	case 1:
+		content, err := ioutil.ReadFile(matches[0])
+		if err != nil {
+			return nil, err
+		}
+		meta.Content = string(content)
+	default:
+		return nil, fmt.Errorf("found multiple %s* content files", contentPrefix)
+	}
+
+	template := &apiv1.CloudTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: meta.Annotations,
+			Labels:      meta.Labels,
+			Name:        name,
+		},
+		Spec: meta.TemplateSpec,
+	}
+	if template.Labels == nil {
+		template.Labels = make(map[string]string, 0)
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string, 0)
+	}
+	template.Labels[models.ManagedByLabel] = models.ManagedByBundle
+	template.Annotations[models.BundleChecksumAnnotation] = getTemplateChecksum(template)
+
+	if errs := template.IsValid(); len(errs) > 0 {
+		return nil, utils.GetErrors(errs)
+	}
+
+	return template, nil
+}
+
+// getTemplateChecksum returns a checksum of the specification and metadata of the template
+func getTemplateChecksum(template *apiv1.CloudTemplate) string {
+	encoded, _ := yaml.Marshal(struct {
+		Annotations map[string]string  `json:"annotations"`
+		Labels      map[string]string  `json:"labels"`
+		Spec        apiv1.TemplateSpec `json:"spec"`
+	}{
+		Annotations: template.Annotations,
+		Labels:      template.Labels,
+		Spec:        template.Spec,
+	})
+
+	return utils.ContentChecksum(string(encoded))
+}
+
+// isManaged checks if the template is managed by the bundle sync
+func isManaged(template *apiv1.CloudTemplate) bool {
+	return template.GetLabels()[models.ManagedByLabel] == models.ManagedByBundle
+}
+
+// isBundleDir checks the directory exists
+func isBundleDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func writeTestBundle(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "bundle")
+	require.NoError(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func TestReadBundle(t *testing.T) {
+	dir := writeTestBundle(t, map[string]string{
+		"s3.bucket/metadata.yaml": "format: yaml\nretention: 1h\nlabels:\n  team: storage\nparameters:\n- name: bucket\n  description: the bucket name\n",
+		"s3.bucket/template.yaml": "AWSTemplateFormatVersion: \"2010-09-09\"\n",
+		"base/metadata.yaml":      "format: json\nretention: 1h\n",
+		"base/template.json":      "{}",
+		".git/metadata.yaml":      "ignored",
+		"README.md":               "ignored",
+	})
+	defer os.RemoveAll(dir)
+
+	list, err := readBundle(dir)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "base", list[0].Name)
+	assert.Equal(t, "s3.bucket", list[1].Name)
+
+	template := list[1]
+	assert.Equal(t, "AWSTemplateFormatVersion: \"2010-09-09\"\n", template.Spec.Content)
+	assert.Equal(t, "storage", template.Labels["team"])
+	assert.True(t, isManaged(template))
+	assert.NotEmpty(t, template.Annotations[models.BundleChecksumAnnotation])
+	require.Len(t, template.Spec.Parameters, 1)
+}
+
+func TestReadBundleChecksumChanges(t *testing.T) {
+	dir := writeTestBundle(t, map[string]string{
+		"bucket/metadata.yaml": "format: yaml\nretention: 1h\n",
+		"bucket/template.yaml": "a: b\n",
+	})
+	defer os.RemoveAll(dir)
+
+	before, err := readBundle(dir)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bucket/template.yaml"), []byte("a: c\n"), 0644))
+	after, err := readBundle(dir)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, before[0].Annotations[models.BundleChecksumAnnotation], after[0].Annotations[models.BundleChecksumAnnotation])
+}
+
+func TestReadBundleInvalid(t *testing.T) {
+	cases := []map[string]string{
+		{"bucket/template.yaml": "a: b\n"},
+		{"bucket/metadata.yaml": "format: yaml\nretention: 1h\n"},
+		{"bucket/metadata.yaml": "format: yaml\nretention: 1h\n", "bucket/template.yaml": "a: b", "bucket/template.json": "{}"},
+		{"bucket/metadata.yaml": ": not yaml", "bucket/template.yaml": "a: b"},
+	}
+	for i, c := range cases {
+		dir := writeTestBundle(t, c)
+		_, err := readBundle(dir)
+		assert.Error(t, err, "case %d should have failed", i)
+		os.RemoveAll(dir)
+	}
+}
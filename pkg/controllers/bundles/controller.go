@@ -0,0 +1,281 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+const (
+	// syncInterval is the interval the bundle directory is synchronized
+	syncInterval = time.Second * 30
+)
+
+// controller is responsible for synchronizing the templates in the bundle directory into the
+// cloud templates; only templates carrying the managed label are updated or pruned
+type controller struct {
+	// config are the controller config
+	config *api.Config
+	// options are the controller options
+	options *api.Options
+	// waitgroup for the tasks
+	waitgroup *sync.WaitGroup
+}
+
+// New creates and returns a bundles controller
+func New(options *api.Options) (api.Controller, error) {
+	if options.Config.TemplateBundleDir == "" {
+		return nil, fmt.Errorf("no template bundle directory defined")
+	}
+
+	return &controller{
+		config:    options.Config,
+		options:   options,
+		waitgroup: &sync.WaitGroup{},
+	}, nil
+}
+
+// Run is responsible for kicking off the controller
+func (c *controller) Run(ctx context.Context) error {
+	log.WithFields(log.Fields{
+		"directory": c.config.TemplateBundleDir,
+	}).Info("starting the template bundles controller")
+
+	// @step: start the service in the background
+	go c.processItems(ctx)
+	// @step: wait for an exit signal
+	<-ctx.Done()
+
+	return nil
+}
+
+// processItems is the main entrypoint for the service loop
+func (c *controller) processItems(ctx context.Context) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.processEvent(ctx); err != nil {
+			syncErrors.Inc()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processEvent is responsible for synchronizing the bundle with the cloud templates
+func (c *controller) processEvent(ctx context.Context) error {
+	syncCounter.Inc()
+
+	c.waitgroup.Add(1)
+	defer c.waitgroup.Done()
+
+	// @step: we need to check if we are the leader
+	if leader := c.options.IsLeader(); !leader {
+		log.WithFields(log.Fields{
+			"controller": c.Name(),
+		}).Debug("skipping the bundle sync, controller not the leader")
+
+		return nil
+	}
+
+	if err := c.sync(); err != nil {
+		log.WithFields(log.Fields{
+			"directory": c.config.TemplateBundleDir,
+			"error":     err.Error(),
+		}).Error("failed to synchronize the template bundle")
+
+		return err
+	}
+
+	return nil
+}
+
+// sync creates and updates the templates in the bundle and prunes the managed templates no
+// longer in the bundle, unless they are still used by a resource or template
+func (c *controller) sync() error {
+	// @step: an unreadable bundle must never be treated as empty, else we would prune everything
+	if err := isBundleDir(c.config.TemplateBundleDir); err != nil {
+		return err
+	}
+	desired, err := readBundle(c.config.TemplateBundleDir)
+	if err != nil {
+		return err
+	}
+
+	list, err := c.options.ResourceClient.CloudV1().CloudTemplates().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*apiv1.CloudTemplate, len(list.Items))
+	for i := range list.Items {
+		current[list.Items[i].Name] = &list.Items[i]
+	}
+
+	var failures []string
+
+	// @step: create or update the templates in the bundle
+	names := make(map[string]bool, len(desired))
+	for _, x := range desired {
+		names[x.Name] = true
+		if err := c.apply(x, current[x.Name]); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", x.Name, err))
+		}
+	}
+
+	// @step: prune the managed templates which have been removed from the bundle
+	for _, x := range list.Items {
+		if !isManaged(&x) || names[x.Name] {
+			continue
+		}
+		if err := c.prune(&x, list.Items); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", x.Name, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to synchronize templates: %s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// apply creates or updates the cloud template from the bundle
+func (c *controller) apply(template, existing *apiv1.CloudTemplate) error {
+	client := c.options.ResourceClient.CloudV1().CloudTemplates()
+	checksum := template.Annotations[models.BundleChecksumAnnotation]
+
+	switch {
+	case existing == nil:
+		log.WithFields(log.Fields{
+			"name": template.Name,
+		}).Info("creating the cloud template from the bundle")
+
+		if _, err := client.Create(template); err != nil {
+			return err
+		}
+		syncActions.WithLabelValues("created").Inc()
+
+	case !isManaged(existing):
+		log.WithFields(log.Fields{
+			"name": template.Name,
+		}).Warn("cloud template exists but is not managed by the bundle, skipping")
+
+		syncActions.WithLabelValues("skipped").Inc()
+
+	case existing.Annotations[models.BundleChecksumAnnotation] != checksum:
+		log.WithFields(log.Fields{
+			"checksum": checksum,
+			"name":     template.Name,
+		}).Info("updating the cloud template from the bundle")
+
+		update := existing.DeepCopy()
+		update.Annotations = template.Annotations
+		update.Labels = template.Labels
+		update.Spec = template.Spec
+
+		if _, err := client.Update(update); err != nil {
+			return err
+		}
+		syncActions.WithLabelValues("updated").Inc()
+	}
+
+	return nil
+}
+
+// prune deletes the managed template unless a resource or another template still references it, i.e.
+// extends it, includes it as a fragment or names it as the successor
+func (c *controller) prune(template *apiv1.CloudTemplate, templates []apiv1.CloudTemplate) error {
+	resources, err := utils.FindCloudResourcesByTemplate(c.options.ResourceClient, template.Name)
+	if err != nil {
+		return err
+	}
+	var users []string
+	for _, x := range resources {
+		users = append(users, fmt.Sprintf("%s/%s", x.Namespace, x.Name))
+	}
+	for _, x := range templates {
+		if x.Name != template.Name && isTemplateUser(&x, template.Name) {
+			users = append(users, x.Name)
+		}
+	}
+
+	if len(users) > 0 {
+		log.WithFields(log.Fields{
+			"name":  template.Name,
+			"users": strings.Join(users, ","),
+		}).Warn("cloud template removed from the bundle is still in use, retaining")
+
+		syncActions.WithLabelValues("retained").Inc()
+
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"name": template.Name,
+	}).Info("pruning the cloud template removed from the bundle")
+
+	if err := c.options.ResourceClient.CloudV1().CloudTemplates().Delete(template.Name, &metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	syncActions.WithLabelValues("pruned").Inc()
+
+	return nil
+}
+
+// isTemplateUser checks if the template extends, includes or is succeeded by the named template
+func isTemplateUser(template *apiv1.CloudTemplate, name string) bool {
+	if template.Spec.Extends == name {
+		return true
+	}
+	if template.Spec.Successor != nil && template.Spec.Successor.Name == name {
+		return true
+	}
+	for _, x := range template.Spec.Fragments {
+		if x == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Name returns the name of the controller
+func (c *controller) Name() string {
+	return "bundles"
+}
+
+// Wait returns the task group stopped
+func (c *controller) Wait() {
+	c.waitgroup.Wait()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/client/clientset/versioned/fake"
+	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func TestPruneRetainsTemplatesInUse(t *testing.T) {
+	managed := map[string]string{models.ManagedByLabel: models.ManagedByBundle}
+	templates := []apiv1.CloudTemplate{
+		{ObjectMeta: metav1.ObjectMeta{Name: "base", Labels: managed}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "included", Labels: managed}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "successor", Labels: managed}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unused", Labels: managed}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "child"},
+			Spec: apiv1.TemplateSpec{
+				Extends:   "base",
+				Fragments: []string{"included"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "deprecated"},
+			Spec: apiv1.TemplateSpec{
+				Deprecated: true,
+				Successor:  &apiv1.TemplateSuccessor{Name: "successor"},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(&templates[0], &templates[1], &templates[2], &templates[3], &templates[4], &templates[5])
+	c := &controller{options: &api.Options{ResourceClient: client}}
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, c.prune(&templates[i], templates))
+	}
+
+	// @check only the template no longer referenced is pruned
+	for _, name := range []string{"base", "included", "successor"} {
+		_, err := client.CloudV1().CloudTemplates().Get(name, metav1.GetOptions{})
+		assert.NoError(t, err, "template: %s should be retained", name)
+	}
+	_, err := client.CloudV1().CloudTemplates().Get("unused", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	syncActions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bundles_sync_actions_total",
+			Help: "The total number of templates created, updated, pruned or retained by the bundle sync",
+		},
+		[]string{"action"},
+	)
+	syncCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "bundles_sync_run_total",
+			Help: "The total number of invocations for the bundles controller",
+		},
+	)
+	syncErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "bundles_sync_error_total",
+			Help: "A total number of errors encountered by the bundles controller",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(syncActions)
+	prometheus.MustRegister(syncCounter)
+	prometheus.MustRegister(syncErrors)
+}
@@ -115,15 +115,15 @@ func (c *controller) processEvent(ctx context.Context) error {
 			}
 
 			// @check if the stack is a in deletion failed state
-			switch x.Status.Status {
-			case models.StatusDone, models.StatusFailed:
+			switch x.Status.Phase {
+			case models.PhaseFailed, models.PhaseReady, models.PhaseRolledBack:
 			default:
 				log.WithFields(log.Fields{
 					"name":     x.Name,
 					"reason":   x.Status.Reason,
 					"resource": x.Spec.Name,
 					"stack":    x.Name,
-					"status":   x.Status.Status,
+					"phase":    x.Status.Phase,
 				}).Info("refusing to delete the stack due to current failed state")
 
 				continue
@@ -35,13 +35,19 @@ import (
 
 	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
 	"github.com/gambol99/resources/pkg/client/clientset/versioned"
+	resourcescheme "github.com/gambol99/resources/pkg/client/clientset/versioned/scheme"
 	"github.com/gambol99/resources/pkg/cloud/aws"
 	"github.com/gambol99/resources/pkg/cloud/null"
+	"github.com/gambol99/resources/pkg/controllers/admission"
 	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/controllers/bundles"
 	"github.com/gambol99/resources/pkg/controllers/cleanup"
+	"github.com/gambol99/resources/pkg/controllers/policies"
 	"github.com/gambol99/resources/pkg/controllers/resources"
 	"github.com/gambol99/resources/pkg/controllers/templates"
 	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/policy"
+	"github.com/gambol99/resources/pkg/utils"
 	"github.com/gambol99/resources/pkg/version"
 )
 
@@ -52,6 +58,7 @@ type ResourceController struct {
 	cloud     models.CloudProvider
 	config    *api.Config
 	election  api.Leadership
+	policies  *policy.RegoEngine
 	recorder  record.EventRecorder
 	routines  []api.Controller
 }
@@ -76,6 +83,7 @@ func (r *ResourceController) Run(ctx context.Context) error {
 		log.Infof("initializing the cloud provider: %s", r.config.CloudProvider)
 		if r.cloud, err = makeCloudProvider(r.config.CloudProvider, &models.ProviderConfig{
 			ClusterName: r.config.ClusterName,
+			LookupTTL:   r.config.LookupTTL,
 			Name:        r.config.Name,
 		}); err != nil {
 			return fmt.Errorf("unable to initialize cloud provider: %s", err)
@@ -100,7 +108,24 @@ func (r *ResourceController) Run(ctx context.Context) error {
 		}
 	}
 
+	// @step: create the rego policy engine and load any policies from disk
+	if r.policies == nil {
+		r.policies = policy.NewRegoEngine()
+		if r.config.PolicyDir != "" {
+			log.WithFields(log.Fields{
+				"path": r.config.PolicyDir,
+			}).Info("loading the rego policies from directory")
+
+			if err := r.policies.LoadDirectory(r.config.PolicyDir); err != nil {
+				return err
+			}
+		}
+	}
+
 	if r.recorder == nil {
+		// @step: register our types so events can reference the cloud resources
+		resourcescheme.AddToScheme(scheme.Scheme)
+
 		bc := record.NewBroadcaster()
 		bc.StartRecordingToSink(&core.EventSinkImpl{Interface: r.client.CoreV1().Events("")})
 		r.recorder = bc.NewRecorder(scheme.Scheme, v1.EventSource{Component: ""})
@@ -118,7 +143,10 @@ func (r *ResourceController) Run(ctx context.Context) error {
 		Client:         r.client,
 		Cloud:          r.cloud,
 		Config:         r.config,
+		Content:        utils.NewContentLoader(r.client, r.config.TemplatesDir),
 		Election:       r.election,
+		MaxRetries:     r.config.MaxRetries,
+		Policies:       r.policies,
 		Record:         r.recorder,
 		ResourceClient: r.clientset,
 		Threadness:     r.config.Threadness,
@@ -137,7 +165,26 @@ func (r *ResourceController) Run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("unable to create the cloud templates controller: %s", err)
 	}
-	r.routines = []api.Controller{cleanup, resourcesCtrl, templatesCtrl}
+	policiesCtrl, err := policies.New(options)
+	if err != nil {
+		return fmt.Errorf("unable to create the policies controller: %s", err)
+	}
+	r.routines = []api.Controller{cleanup, policiesCtrl, resourcesCtrl, templatesCtrl}
+
+	if r.config.TemplateBundleDir != "" {
+		bundlesCtrl, err := bundles.New(options)
+		if err != nil {
+			return fmt.Errorf("unable to create the template bundles controller: %s", err)
+		}
+		r.routines = append(r.routines, bundlesCtrl)
+	}
+	if r.config.EnableAdmission {
+		admissionCtrl, err := admission.New(options)
+		if err != nil {
+			return fmt.Errorf("unable to create the admission controller: %s", err)
+		}
+		r.routines = append(r.routines, admissionCtrl)
+	}
 
 	var errorCh chan error
 
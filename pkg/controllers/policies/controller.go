@@ -0,0 +1,180 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	inform "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// the controller is used to load the rego policies from the configmaps into the policy engine;
+// unlike the other controllers every instance runs it so a new leader has the policies loaded
+type controller struct {
+	// informer is the lister
+	informer cache.SharedIndexInformer
+	// config are the controller config
+	config *api.Config
+	// options are the controller options
+	options *api.Options
+	// waitgroup is a wait group for the workers
+	waitgroup *sync.WaitGroup
+}
+
+// New returns a new policies controller
+func New(options *api.Options) (api.Controller, error) {
+	if options.Policies == nil {
+		return nil, fmt.Errorf("no policy engine has been provided")
+	}
+
+	return &controller{
+		config:    options.Config,
+		options:   options,
+		waitgroup: &sync.WaitGroup{},
+	}, nil
+}
+
+// Run is responsible for starting the controller up
+func (c *controller) Run(ctx context.Context) error {
+	log.WithFields(log.Fields{
+		"label":     models.PolicyLabel,
+		"namespace": c.config.PolicyNamespace,
+	}).Info("watching configmaps for rego policies")
+
+	// @step: we create a configmap informer filtered on the policy label
+	c.informer = inform.NewFilteredConfigMapInformer(c.options.Client, c.config.PolicyNamespace, c.options.ResyncDuration, cache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = fmt.Sprintf("%s=true", models.PolicyLabel)
+		})
+	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.updated(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.updated(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.deleted(obj)
+		},
+	})
+
+	// @step: start the shared index informer
+	stopCh := make(chan struct{}, 0)
+	go c.informer.Run(stopCh)
+
+	log.WithFields(log.Fields{"controller": c.Name()}).Info("waiting for controller caches to synchronize")
+	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+		runtime.HandleError(fmt.Errorf("%s controller timed out waiting for caches to sync", c.Name()))
+		return fmt.Errorf("%s controller timed out waiting for cache sync", c.Name())
+	}
+	// @step: the event handlers may still be catching up with the cache, so we load the policies
+	// from the cache ourselves before marking the engine as synced
+	for _, obj := range c.informer.GetStore().List() {
+		c.updated(obj)
+	}
+	c.options.Policies.SetSynced()
+
+	// @step: wait for a signal to stop
+	select {
+	case <-ctx.Done():
+		close(stopCh)
+	}
+	log.WithFields(log.Fields{"controller": c.Name()}).Info("shutting down the controller")
+
+	return nil
+}
+
+// updated is called when a policy configmap is created or updated
+func (c *controller) updated(obj interface{}) {
+	c.waitgroup.Add(1)
+	defer c.waitgroup.Done()
+
+	cm, ok := obj.(*core.ConfigMap)
+	if !ok {
+		return
+	}
+	source := getSourceName(cm.Namespace, cm.Name)
+
+	if err := c.options.Policies.SetModules(source, cm.Data); err != nil {
+		metricErrorTotal.Inc()
+		log.WithFields(log.Fields{
+			"error":  err.Error(),
+			"source": source,
+		}).Error("failed to load the rego policies, retaining the previous policies")
+
+		return
+	}
+	metricLoadedTotal.Inc()
+
+	log.WithFields(log.Fields{
+		"source": source,
+	}).Info("successfully loaded the rego policies")
+}
+
+// deleted is called when a policy configmap is removed
+func (c *controller) deleted(obj interface{}) {
+	c.waitgroup.Add(1)
+	defer c.waitgroup.Done()
+
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	cm, ok := obj.(*core.ConfigMap)
+	if !ok {
+		return
+	}
+	source := getSourceName(cm.Namespace, cm.Name)
+
+	if err := c.options.Policies.DeleteModules(source); err != nil {
+		metricErrorTotal.Inc()
+		log.WithFields(log.Fields{
+			"error":  err.Error(),
+			"source": source,
+		}).Error("failed to remove the rego policies")
+
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"source": source,
+	}).Info("removed the rego policies")
+}
+
+// getSourceName returns the source name for the policies in a configmap
+func getSourceName(namespace, name string) string {
+	return fmt.Sprintf("configmap/%s/%s", namespace, name)
+}
+
+// Name returns the name of the controller
+func (c *controller) Name() string {
+	return "policies"
+}
+
+// Wait returns the task group stopped
+func (c *controller) Wait() {
+	c.waitgroup.Wait()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/policy"
+)
+
+const testRegoPolicy = `
+package cloud.resources
+
+deny[msg] {
+	input.rendered.Resources[name].Type == "AWS::IAM::User"
+	msg := sprintf("resource: %s, users are not permitted", [name])
+}
+`
+
+func TestPoliciesLoadedBeforeSynced(t *testing.T) {
+	engine := policy.NewRegoEngine()
+	client := kfake.NewSimpleClientset(&core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "policies",
+			Namespace: "kube-system",
+			Labels:    map[string]string{models.PolicyLabel: "true"},
+		},
+		Data: map[string]string{"users.rego": testRegoPolicy},
+	})
+	c, err := New(&api.Options{
+		Client:   client,
+		Config:   &api.Config{PolicyNamespace: "kube-system"},
+		Policies: engine,
+	})
+	require.NoError(t, err)
+	assert.False(t, engine.HasSynced())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Run(ctx)
+
+	// @check the policies are loaded by the time the engine is marked as synced
+	require.NoError(t, wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return engine.HasSynced(), nil
+	}))
+	assert.True(t, engine.HasPolicies())
+	assert.Equal(t, []string{"configmap/kube-system/policies"}, engine.Sources())
+}
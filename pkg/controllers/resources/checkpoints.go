@@ -0,0 +1,218 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+const (
+	// credentialsKey is the key in the staging secret holding the minted credentials
+	credentialsKey = "credentials"
+	// defaultStagingNamespace is the namespace credentials are staged in when the controller has none
+	defaultStagingNamespace = "kube-system"
+)
+
+// credentialsSecretName returns the name of the secret the credentials minted from the stack are staged in
+func credentialsSecretName(stackname string) string {
+	return fmt.Sprintf("%s-credentials", stackname)
+}
+
+// stagingNamespace returns the namespace of the controller the credentials are staged in; they are never
+// staged in the namespace of the resource where the tenant could read them
+func (c *controller) stagingNamespace() string {
+	if c.config.ElectionNamespace != "" {
+		return c.config.ElectionNamespace
+	}
+
+	return defaultStagingNamespace
+}
+
+// reconcileSteps runs the steps which follow the stack being applied, resuming from the last checkpoint
+// recorded for the stack. Each step is checkpointed in the status once complete, the credentials are
+// staged in a secret in the controller namespace before their checkpoint so a resume never has to mint
+// them twice, the staging secret is removed once the secrets have been written
+func (c *controller) reconcileSteps(ctx context.Context, resource *apiv1.CloudResource, template *apiv1.CloudTemplate, stack *models.Stack) error {
+	checkpoint, err := c.getCheckpoint(resource, stack)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the reconcile checkpoint: %s", err)
+	}
+	if checkpoint == nil {
+		if checkpoint, err = c.setCheckpoint(resource, stack, apiv1.CheckpointStackApplied); err != nil {
+			return err
+		}
+	}
+	if checkpoint.Reached(apiv1.CheckpointSecretsWritten) {
+		log.WithFields(log.Fields{
+			"checksum":  checkpoint.Checksum,
+			"namespace": resource.Namespace,
+			"resource":  resource.Name,
+		}).Debug("reconcile of the stack has already completed")
+
+		// @check the staged credentials are removed should the controller have died before doing so
+		if template.Spec.Credentials {
+			return c.deleteStagedCredentials(stack.Name)
+		}
+
+		return nil
+	}
+	log.WithFields(log.Fields{
+		"checkpoint": checkpoint.Step,
+		"namespace":  resource.Namespace,
+		"resource":   resource.Name,
+	}).Info("resuming the reconcile of the stack from the checkpoint")
+
+	// @step: if the stack has any credentials we need to generate them
+	var credentials map[string]models.Credential
+
+	if template.Spec.Credentials {
+		if !checkpoint.Reached(apiv1.CheckpointCredentialsMinted) {
+			credentials, err = c.updateCloudCredentials(ctx, resource, stack)
+			if err != nil {
+				return fmt.Errorf("unable to update / create credentials from stack: %s", err)
+			}
+			if err := c.stageCloudCredentials(resource, stack, credentials); err != nil {
+				return fmt.Errorf("unable to stage the credentials from stack: %s", err)
+			}
+			if _, err := c.setCheckpoint(resource, stack, apiv1.CheckpointCredentialsMinted); err != nil {
+				return err
+			}
+		} else if credentials, err = c.getStagedCredentials(resource, stack); err != nil {
+			return fmt.Errorf("unable to retrieve the staged credentials from stack: %s", err)
+		}
+	}
+
+	// @step: we need to map the outputs, secrets and credentials into the user namespace
+	if err := c.updateCloudSecrets(ctx, resource, stack, credentials); err != nil {
+		return fmt.Errorf("unable to update the kubernetes secrets: %s", err)
+	}
+	if _, err := c.setCheckpoint(resource, stack, apiv1.CheckpointSecretsWritten); err != nil {
+		return err
+	}
+
+	// @step: the staged credentials are no longer required once the secrets have been written
+	if template.Spec.Credentials {
+		if err := c.deleteStagedCredentials(stack.Name); err != nil {
+			log.WithFields(log.Fields{
+				"error":     err.Error(),
+				"namespace": resource.Namespace,
+				"resource":  resource.Name,
+			}).Warn("unable to delete the staged credentials of the stack")
+		}
+	}
+
+	return nil
+}
+
+// getCheckpoint retrieves the checkpoint of the resource, returning nil when there is none or the
+// checkpoint is for a previous checksum or reconcile request of the stack
+func (c *controller) getCheckpoint(resource *apiv1.CloudResource, stack *models.Stack) (*apiv1.ReconcileCheckpoint, error) {
+	status, err := c.options.ResourceClient.CloudV1().CloudStatuses(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	checkpoint := status.Checkpoint
+	if checkpoint == nil || checkpoint.Checksum != stack.CheckSum() || checkpoint.Reconcile != stack.Reconciled() {
+		return nil, nil
+	}
+
+	return checkpoint, nil
+}
+
+// setCheckpoint records the step of the reconcile of the stack as completed in the status
+func (c *controller) setCheckpoint(resource *apiv1.CloudResource, stack *models.Stack, step string) (*apiv1.ReconcileCheckpoint, error) {
+	checkpoint := &apiv1.ReconcileCheckpoint{
+		Checksum:           stack.CheckSum(),
+		LastTransitionTime: metav1.Now(),
+		Reconcile:          stack.Reconciled(),
+		Step:               step,
+	}
+
+	err := utils.Retry(3, time.Second*2, func() error {
+		status, err := c.options.ResourceClient.CloudV1().CloudStatuses(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		status.Checkpoint = checkpoint
+		_, err = c.options.ResourceClient.CloudV1().CloudStatuses(resource.Namespace).Update(status)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to record the checkpoint: %s, error: %s", step, err)
+	}
+
+	log.WithFields(log.Fields{
+		"checkpoint": step,
+		"checksum":   checkpoint.Checksum,
+		"namespace":  resource.Namespace,
+		"resource":   resource.Name,
+	}).Info("recorded the reconcile checkpoint of the stack")
+
+	checkpointsCounter.WithLabelValues(step).Inc()
+
+	if c.onCheckpoint != nil {
+		c.onCheckpoint(checkpoint)
+	}
+
+	return checkpoint, nil
+}
+
+// stageCloudCredentials is responsible for writing the credentials minted from the stack to the staging secret
+func (c *controller) stageCloudCredentials(resource *apiv1.CloudResource, stack *models.Stack, credentials map[string]models.Credential) error {
+	encoded, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+
+	return utils.UpdateKubernetesSecret(c.options.Client, credentialsSecretName(stack.Name), c.stagingNamespace(), map[string]string{
+		credentialsKey: string(encoded),
+	})
+}
+
+// deleteStagedCredentials is responsible for removing the credentials staged for the stack
+func (c *controller) deleteStagedCredentials(stackname string) error {
+	return utils.DeleteKubernetesSecret(c.options.Client, credentialsSecretName(stackname), c.stagingNamespace())
+}
+
+// getStagedCredentials is responsible for retrieving the credentials staged for the stack
+func (c *controller) getStagedCredentials(resource *apiv1.CloudResource, stack *models.Stack) (map[string]models.Credential, error) {
+	values, err := utils.FindKubernetesSecret(c.options.Client, credentialsSecretName(stack.Name), c.stagingNamespace())
+	if err != nil {
+		return nil, err
+	}
+	credentials := make(map[string]models.Credential, 0)
+	if err := json.Unmarshal([]byte(values[credentialsKey]), &credentials); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
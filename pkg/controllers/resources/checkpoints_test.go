@@ -0,0 +1,256 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kfake "k8s.io/client-go/kubernetes/fake"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/client/clientset/versioned"
+	"github.com/gambol99/resources/pkg/client/clientset/versioned/fake"
+	"github.com/gambol99/resources/pkg/cloud/null"
+	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// errKilled is raised by the checkpoint hook to kill the controller between the steps
+var errKilled = errors.New("controller killed")
+
+// testCloud is the null provider minting a new credential on each call
+type testCloud struct {
+	models.CloudProvider
+	minted int
+}
+
+func (t *testCloud) Credentials(context.Context, string) ([]models.Credential, error) {
+	t.minted++
+
+	return []models.Credential{{ID: "user", User: fmt.Sprintf("key-%d", t.minted), Secret: "secret"}}, nil
+}
+
+func newTestCloud(t *testing.T) *testCloud {
+	cloud, err := null.New(&models.ProviderConfig{ClusterName: "test", Name: "test"})
+	require.NoError(t, err)
+
+	return &testCloud{CloudProvider: cloud}
+}
+
+func newTestClients() (kubernetes.Interface, versioned.Interface) {
+	return kfake.NewSimpleClientset(
+			&core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+		), fake.NewSimpleClientset(
+			&apiv1.CloudTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "bucket"},
+				Spec: apiv1.TemplateSpec{
+					Content:     "Resources: {}\n",
+					Credentials: true,
+					Format:      apiv1.FormatYAML,
+					Retention:   &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			&apiv1.CloudResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "bucket", Namespace: "test"},
+				Spec: apiv1.CloudResourceSpec{
+					TemplateName: "bucket",
+					Secrets: []apiv1.Secret{{
+						Name: "bucket",
+						Values: []apiv1.SecretValue{
+							{Type: apiv1.SecretTypeCredential, Key: "AWS_ACCESS_KEY_ID", Value: "user.username"},
+							{Type: apiv1.SecretTypeCredential, Key: "AWS_SECRET_ACCESS_KEY", Value: "user.secret"},
+						},
+					}},
+				},
+			},
+		)
+}
+
+func newTestResourceController(t *testing.T, cloud models.CloudProvider, client kubernetes.Interface, resources versioned.Interface) *controller {
+	config := &api.Config{ClusterName: "test", Name: "test"}
+	c, err := New(&api.Options{
+		Client:         client,
+		Cloud:          cloud,
+		Config:         config,
+		ResourceClient: resources,
+	})
+	require.NoError(t, err)
+
+	return c.(*controller)
+}
+
+// runReconcile reconciles the resource until it completes or the controller is killed; the first pass
+// starts the operation on the stack and the second picks up the completed stack
+func runReconcile(t *testing.T, c *controller) (killed bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errKilled {
+				panic(r)
+			}
+			killed = true
+		}
+	}()
+	for i := 0; i < 2; i++ {
+		resource, ferr := utils.FindCloudResource(c.options.ResourceClient, "bucket", "test")
+		require.NoError(t, ferr)
+		if err = c.updated(resource); err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+func getTestCheckpoint(t *testing.T, resources versioned.Interface) *apiv1.ReconcileCheckpoint {
+	status, err := resources.CloudV1().CloudStatuses("test").Get("bucket", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	return status.Checkpoint
+}
+
+func TestReconcileResumesFromCheckpoint(t *testing.T) {
+	steps := []string{
+		apiv1.CheckpointStackApplied,
+		apiv1.CheckpointCredentialsMinted,
+		apiv1.CheckpointSecretsWritten,
+	}
+	for _, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			cloud := newTestCloud(t)
+			client, resources := newTestClients()
+
+			// @step: kill the leader once the step has been checkpointed
+			leader := newTestResourceController(t, cloud, client, resources)
+			leader.onCheckpoint = func(x *apiv1.ReconcileCheckpoint) {
+				if x.Step == step {
+					panic(errKilled)
+				}
+			}
+			killed, err := runReconcile(t, leader)
+			require.NoError(t, err)
+			require.True(t, killed)
+			require.NotNil(t, getTestCheckpoint(t, resources))
+			assert.Equal(t, step, getTestCheckpoint(t, resources).Step)
+
+			// @step: a new leader takes over the resource and resumes
+			follower := newTestResourceController(t, cloud, client, resources)
+			killed, err = runReconcile(t, follower)
+			require.NoError(t, err)
+			require.False(t, killed)
+
+			assert.Equal(t, 1, cloud.minted)
+			assert.Equal(t, apiv1.CheckpointSecretsWritten, getTestCheckpoint(t, resources).Step)
+			secret, err := utils.FindKubernetesSecret(client, "bucket", "test")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"AWS_ACCESS_KEY_ID": "key-1", "AWS_SECRET_ACCESS_KEY": "secret"}, secret)
+
+			// @check the staged credentials have been removed and never reached the tenant namespace
+			stackname := models.GetStackName("bucket", "test")
+			for _, namespace := range []string{"test", defaultStagingNamespace} {
+				_, err = client.CoreV1().Secrets(namespace).Get(credentialsSecretName(stackname), metav1.GetOptions{})
+				assert.True(t, kerrors.IsNotFound(err), "namespace: %s", namespace)
+			}
+		})
+	}
+}
+
+func TestReconcileCheckpointReset(t *testing.T) {
+	cloud := newTestCloud(t)
+	client, resources := newTestClients()
+	c := newTestResourceController(t, cloud, client, resources)
+
+	_, err := runReconcile(t, c)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cloud.minted)
+
+	// @check a reconcile request updates the stack and starts a new reconcile
+	resource, err := utils.FindCloudResource(resources, "bucket", "test")
+	require.NoError(t, err)
+	resource.Annotations = map[string]string{models.ReconcileAnnotation: "now"}
+	_, err = resources.CloudV1().CloudResources("test").Update(resource)
+	require.NoError(t, err)
+
+	_, err = runReconcile(t, c)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cloud.minted)
+
+	checkpoint := getTestCheckpoint(t, resources)
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, "now", checkpoint.Reconcile)
+	assert.Equal(t, apiv1.CheckpointSecretsWritten, checkpoint.Step)
+}
+
+func TestReconcileCheckpointReached(t *testing.T) {
+	var checkpoint *apiv1.ReconcileCheckpoint
+	assert.False(t, checkpoint.Reached(apiv1.CheckpointStackApplied))
+
+	checkpoint = &apiv1.ReconcileCheckpoint{Step: apiv1.CheckpointCredentialsMinted}
+	assert.True(t, checkpoint.Reached(apiv1.CheckpointStackApplied))
+	assert.True(t, checkpoint.Reached(apiv1.CheckpointCredentialsMinted))
+	assert.False(t, checkpoint.Reached(apiv1.CheckpointSecretsWritten))
+
+	checkpoint.Step = "Unknown"
+	assert.False(t, checkpoint.Reached(apiv1.CheckpointStackApplied))
+}
+
+// noUpdatesCloud is the null provider whose updates change nothing
+type noUpdatesCloud struct {
+	*testCloud
+	noop bool
+}
+
+func (n *noUpdatesCloud) Create(ctx context.Context, name string, options *models.CreateOptions) error {
+	if n.noop {
+		return models.ErrNoUpdates
+	}
+
+	return n.testCloud.Create(ctx, name, options)
+}
+
+func TestReconcileNoUpdates(t *testing.T) {
+	cloud := &noUpdatesCloud{testCloud: newTestCloud(t)}
+	client, resources := newTestClients()
+	c := newTestResourceController(t, cloud, client, resources)
+
+	_, err := runReconcile(t, c)
+	require.NoError(t, err)
+
+	// @check an update which changes nothing records no operation to poll
+	resource, err := utils.FindCloudResource(resources, "bucket", "test")
+	require.NoError(t, err)
+	resource.Annotations = map[string]string{models.ReconcileAnnotation: "now"}
+	_, err = resources.CloudV1().CloudResources("test").Update(resource)
+	require.NoError(t, err)
+
+	cloud.noop = true
+	require.NoError(t, c.updated(resource))
+
+	status, err := resources.CloudV1().CloudStatuses("test").Get("bucket", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, status.Operation)
+}
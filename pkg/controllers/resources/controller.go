@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -40,8 +41,18 @@ type controller struct {
 	queue workqueue.RateLimitingInterface
 	// config are the controller config
 	config *api.Config
+	// policies is the informer for the cloud policies
+	policies cache.SharedIndexInformer
+	// retained is the cache of the stacks retained after their resource was deleted
+	retained *retainedStacks
+	// statuses is the informer for the cloud statuses
+	statuses cache.SharedIndexInformer
+	// onCheckpoint is called once a checkpoint of a reconcile has been recorded
+	onCheckpoint func(*apiv1.ReconcileCheckpoint)
 	// options are the controller options
 	options *api.Options
+	// throttle is the backoff of the resources throttled by the cloud provider
+	throttle workqueue.RateLimiter
 	// waitgroup is a wait group for the workers
 	waitgroup *sync.WaitGroup
 }
@@ -50,9 +61,14 @@ type controller struct {
 func New(options *api.Options) (api.Controller, error) {
 	return &controller{
 		config:    options.Config,
+		informer:  inform.NewCloudResourceInformer(options.ResourceClient, "", options.ResyncDuration, cache.Indexers{}),
 		options:   options,
+		policies:  inform.NewCloudPolicyInformer(options.ResourceClient, options.ResyncDuration, cache.Indexers{}),
+		retained:  newRetainedStacks(),
+		statuses:  inform.NewCloudStatusInformer(options.ResourceClient, "", options.ResyncDuration, cache.Indexers{}),
 		waitgroup: &sync.WaitGroup{},
 		queue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		throttle:  workqueue.NewItemExponentialFailureRateLimiter(throttledBaseDelay, throttledMaxDelay),
 	}, nil
 }
 
@@ -60,8 +76,7 @@ func New(options *api.Options) (api.Controller, error) {
 func (c *controller) Run(ctx context.Context) error {
 	log.Infof("starting the %s controller, used to handle the cloud resources", c.Name())
 
-	// @step: we create a namespace informer
-	c.informer = inform.NewCloudResourceInformer(c.options.ResourceClient, "", c.options.ResyncDuration, cache.Indexers{})
+	// @step: handle the changes to the cloud resources
 	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			key, err := cache.MetaNamespaceKeyFunc(obj)
@@ -84,15 +99,38 @@ func (c *controller) Run(ctx context.Context) error {
 	})
 	defer c.queue.ShutDown()
 
-	// @step: start the shared index informer
+	// @step: start the shared index informers
 	stopCh := make(chan struct{}, 0)
 	go c.informer.Run(stopCh)
-
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+	go c.policies.Run(stopCh)
+	go c.statuses.Run(stopCh)
+
+	// @step: wait on the rego policies being loaded, else the resources would be reconciled without
+	// the denies at startup or when taking over the leadership
+	synced := []cache.InformerSynced{c.informer.HasSynced, c.policies.HasSynced, c.statuses.HasSynced}
+	if c.options.Policies != nil {
+		synced = append(synced, c.options.Policies.HasSynced)
+	}
+	if !cache.WaitForCacheSync(stopCh, synced...) {
 		runtime.HandleError(fmt.Errorf("%s controller timed out waiting for caches to sync", c.Name()))
 		return fmt.Errorf("%s controller timed out waiting for cache sync", c.Name())
 	}
 
+	// @step: load the retained stacks for the quotas and refresh them periodically
+	c.syncRetainedStacks(ctx)
+	go func() {
+		ticker := time.NewTicker(retainedSyncInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.syncRetainedStacks(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	// @step: start the workers
 	for i := 0; i < c.options.Threadness; i++ {
 		go c.processItems()
@@ -128,15 +166,28 @@ func (c *controller) processNextItem() bool {
 	err := c.processEvent(key.(string))
 	if err == nil {
 		c.queue.Forget(key)
+		c.throttle.Forget(key)
 		return true
 	}
-	metricErrorTotal.Inc()
+	class := classifyError(err)
+	metricErrorTotal.WithLabelValues(class).Inc()
+
+	// @step: requeue the resource according to the class of the error
+	switch after := c.retryAfter(key, class); after {
+	case retryNever:
+		log.WithFields(log.Fields{
+			"class": class,
+			"error": err.Error(),
+			"key":   key,
+		}).Warn("not retrying the resource, dropping from the queue")
 
-	if c.queue.NumRequeues(key) < c.options.MaxRetries {
-		c.queue.AddRateLimited(key)
-	} else {
 		c.queue.Forget(key)
+		c.throttle.Forget(key)
 		runtime.HandleError(err)
+	case retryRateLimited:
+		c.queue.AddRateLimited(key)
+	default:
+		c.queue.AddAfter(key, after)
 	}
 
 	return true
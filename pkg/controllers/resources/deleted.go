@@ -23,17 +23,18 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
 	"github.com/gambol99/resources/pkg/models"
 	"github.com/gambol99/resources/pkg/utils"
 )
 
 // deleted is responsible for handling the removal of a cloudresource
 func (c *controller) deleted(name, namespace string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*30)
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
 	defer cancel()
 
 	// @step: pull the stack from the cloud provider
-	stackname := getStackName(name, namespace)
+	stackname := models.GetStackName(name, namespace)
 	stack, err := c.options.Cloud.Get(ctx, stackname, &models.GetOptions{})
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -53,6 +54,23 @@ func (c *controller) deleted(name, namespace string) error {
 		"template":  stack.Spec.Template,
 	}).Info("cloud resource stack deletion event")
 
+	// @step: remove any credentials left staged for the stack
+	if err := c.deleteStagedCredentials(stackname); err != nil {
+		return fmt.Errorf("unable to delete the staged credentials of the stack: %s", err)
+	}
+
+	// @step: the deletion is bounded by the stack timeout; the resource has gone so the template or
+	// the controller default applies
+	var template *apiv1.CloudTemplate
+	if x, err := utils.FindCloudTemplate(c.options.ResourceClient, stack.Spec.Template); err == nil {
+		template = x
+	}
+	if timeout := c.getStackTimeout(nil, template); timeout > 0 {
+		var cancelDelete context.CancelFunc
+		ctx, cancelDelete = context.WithTimeout(context.Background(), timeout)
+		defer cancelDelete()
+	}
+
 	// @check if not retention, in which case we can delete straight away
 	if stack.Spec.Retention <= 0 {
 		log.WithFields(log.Fields{
@@ -97,6 +115,7 @@ func (c *controller) deleted(name, namespace string) error {
 
 		return err
 	}
+	c.retained.add(stack)
 
 	return nil
 }
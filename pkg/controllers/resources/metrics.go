@@ -21,14 +21,63 @@ import (
 )
 
 var (
-	metricErrorTotal = prometheus.NewCounter(
+	checkpointsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "resource_controller_reconcile_checkpoints_total",
+			Help: "The total number of reconcile checkpoints recorded by step",
+		},
+		[]string{"step"},
+	)
+	metricErrorTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "resource_controller_errors_total",
-			Help: "The total number of errors encountered by the resource controller",
+			Help: "The total number of errors encountered by the resource controller by class",
+		},
+		[]string{"class"},
+	)
+	operationsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "resource_controller_stack_operations_total",
+			Help: "The total number of operations started on the stacks by type",
+		},
+		[]string{"type"},
+	)
+	operationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "resource_controller_stack_operation_duration_seconds",
+			Help:    "The duration of the operations on the stacks by type and the phase of the stack after",
+			Buckets: []float64{30, 60, 120, 300, 600, 1200, 1800, 3600},
+		},
+		[]string{"type", "phase"},
+	)
+	operationTimeoutsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "resource_controller_stack_operation_timeouts_total",
+			Help: "The total number of operations on the stacks which exceeded the timeout by type and action",
+		},
+		[]string{"type", "action"},
+	)
+	supersededCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "resource_controller_stack_operations_superseded_total",
+			Help: "The total number of changes to the resources while an operation was in progress by policy",
+		},
+		[]string{"policy"},
+	)
+	stackDiffCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "resource_controller_stack_diffs_total",
+			Help: "The total number of stacks found to differ from the template as rendered now",
 		},
 	)
 )
 
 func init() {
+	prometheus.MustRegister(checkpointsCounter)
 	prometheus.MustRegister(metricErrorTotal)
+	prometheus.MustRegister(operationDuration)
+	prometheus.MustRegister(operationTimeoutsCounter)
+	prometheus.MustRegister(operationsCounter)
+	prometheus.MustRegister(stackDiffCounter)
+	prometheus.MustRegister(supersededCounter)
 }
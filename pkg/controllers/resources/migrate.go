@@ -0,0 +1,113 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// migrate is responsible for re-pointing the resource at the successor of the deprecated template;
+// the update to the resource requeues it and the existing stack is updated from the successor
+func (c *controller) migrate(resource *apiv1.CloudResource, template *apiv1.CloudTemplate) error {
+	name := template.Spec.Successor.Name
+
+	successor, err := utils.FindCloudTemplate(c.options.ResourceClient, name)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve successor template: %s, error: %s", name, err)
+	}
+	resolved, err := utils.ResolveCloudTemplate(c.options.ResourceClient, c.options.Content, successor)
+	if err != nil {
+		return fmt.Errorf("unable to resolve successor template: %s, error: %s", name, err)
+	}
+
+	migrated, err := models.MigrateResource(resource, template, resolved.Template)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":     err.Error(),
+			"name":      resource.Name,
+			"namespace": resource.Namespace,
+			"successor": name,
+		}).Error("unable to migrate the cloud resource to the successor template")
+
+		if c.options.Record != nil {
+			c.options.Record.Event(resource, core.EventTypeWarning, models.EventTemplateMigrated, err.Error())
+		}
+
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"name":      resource.Name,
+		"namespace": resource.Namespace,
+		"successor": name,
+		"template":  template.Name,
+	}).Info("migrating the cloud resource to the successor template")
+
+	err = utils.Retry(5, time.Duration(time.Second*3), func() error {
+		_, err := c.options.ResourceClient.CloudV1().CloudResources(resource.Namespace).Update(migrated)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update the migrated cloud resource: %s", err)
+	}
+
+	if c.options.Record != nil {
+		c.options.Record.Eventf(resource, core.EventTypeNormal, models.EventTemplateMigrated,
+			"migrated from the deprecated template: %s to: %s", template.Name, name)
+	}
+
+	return nil
+}
+
+// recordDeprecation logs and raises an event on the resource when the template is deprecated, returning
+// the deprecation condition for the status of the resource
+func (c *controller) recordDeprecation(resource *apiv1.CloudResource, template *apiv1.CloudTemplate) *apiv1.Condition {
+	warning := template.GetDeprecationWarning()
+	if warning == "" {
+		return &apiv1.Condition{
+			Status: apiv1.ConditionFalse,
+			Type:   apiv1.ConditionTemplateDeprecated,
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"name":      resource.Name,
+		"namespace": resource.Namespace,
+		"template":  template.Name,
+		"warning":   warning,
+	}).Warn("cloud resource is using a deprecated template")
+
+	if c.options.Record != nil {
+		c.options.Record.Event(resource, core.EventTypeWarning, models.EventTemplateDeprecated, warning)
+	}
+
+	return &apiv1.Condition{
+		Message: warning,
+		Reason:  models.EventTemplateDeprecated,
+		Status:  apiv1.ConditionTrue,
+		Type:    apiv1.ConditionTemplateDeprecated,
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// reconcileTimeout is the timeout for the cloud calls of a reconcile, none of which wait on the stack
+	reconcileTimeout = time.Minute * 5
+	// operationPollInterval is the interval the stack is polled while an operation is in progress
+	operationPollInterval = time.Second * 15
+)
+
+// newStackOperation returns a new operation on the stack, a zero timeout indicating none
+func newStackOperation(kind string, timeout time.Duration) *apiv1.StackOperation {
+	operation := &apiv1.StackOperation{
+		ID:        fmt.Sprintf("%s-%d", strings.ToLower(kind), time.Now().UnixNano()),
+		StartTime: metav1.Now(),
+		Type:      kind,
+	}
+	if timeout > 0 {
+		operation.Timeout = &metav1.Duration{Duration: timeout}
+	}
+
+	return operation
+}
+
+// checkOperation checks the operation recorded in the status of the resource, returning true and
+// requeuing the resource if it is still in progress
+func (c *controller) checkOperation(ctx context.Context, stackname string, resource *apiv1.CloudResource) (bool, error) {
+	status, err := c.options.ResourceClient.CloudV1().CloudStatuses(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	operation := status.Operation
+	if operation == nil {
+		return false, nil
+	}
+
+	state, err := c.options.Cloud.OperationStatus(ctx, stackname, operation)
+	if err != nil {
+		// @check if the stack has gone we leave it to the update to recreate
+		if err == models.ErrStackNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if state.IsInProgress() {
+		// @check if the operation has exceeded the timeout
+		if operation.HasExpired(time.Now()) {
+			return true, c.timeoutOperation(ctx, stackname, resource, status)
+		}
+		// @check if the resource has changed since the operation was started
+		if err := c.supersedeOperation(ctx, stackname, resource, status); err != nil {
+			return true, err
+		}
+		// @check a timed out operation marked as failed is no longer polled
+		if operation.TimeoutAction == apiv1.TimeoutActionFail {
+			return true, nil
+		}
+		c.requeue(resource, operationPollInterval)
+
+		return true, nil
+	}
+
+	log.WithFields(log.Fields{
+		"duration":  time.Since(operation.StartTime.Time).String(),
+		"id":        operation.ID,
+		"namespace": resource.Namespace,
+		"operation": operation.Type,
+		"phase":     state.Phase,
+		"resource":  resource.Name,
+	}).Info("stack operation has completed")
+
+	operationDuration.WithLabelValues(operation.Type, state.Phase).Observe(time.Since(operation.StartTime.Time).Seconds())
+
+	return false, nil
+}
+
+// requeue adds the resource back onto the queue after the duration
+func (c *controller) requeue(resource *apiv1.CloudResource, after time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(resource)
+	if err != nil {
+		return
+	}
+	c.queue.AddAfter(key, after)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/policy"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// checkCloudPolicies is responsible for evaluating the rendered template against the cloud
+// policies and any rego policies loaded
+func (c *controller) checkCloudPolicies(ctx context.Context, options *models.CreateOptions) error {
+	var violations []policy.Violation
+
+	for _, check := range []func() error{
+		func() error { return c.checkConstraintPolicies(options.Resource, options.Content) },
+		func() error { return c.checkRegoPolicies(ctx, options) },
+	} {
+		err := check()
+		if err == nil {
+			continue
+		}
+		if !policy.IsViolation(err) {
+			return err
+		}
+		violations = append(violations, err.(*policy.ViolationError).Violations...)
+	}
+	if len(violations) > 0 {
+		return &policy.ViolationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// checkRegoPolicies is responsible for evaluating the resource against the rego policies
+func (c *controller) checkRegoPolicies(ctx context.Context, options *models.CreateOptions) error {
+	if c.options.Policies == nil || !c.options.Policies.HasPolicies() {
+		return nil
+	}
+	resource := options.Resource
+
+	input, err := policy.NewRegoInput(resource, options.Template, options.NamespaceLabels, options.Content)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"namespace": resource.Namespace,
+		"resource":  resource.Name,
+		"sources":   c.options.Policies.Sources(),
+	}).Debug("evaluating the resource against the rego policies")
+
+	return c.options.Policies.Evaluate(ctx, input)
+}
+
+// checkConstraintPolicies is responsible for evaluating the rendered template against the cloud policies
+func (c *controller) checkConstraintPolicies(resource *apiv1.CloudResource, content string) error {
+	var policies []apiv1.CloudPolicy
+	for _, obj := range c.policies.GetStore().List() {
+		if x, ok := obj.(*apiv1.CloudPolicy); ok {
+			policies = append(policies, *x)
+		}
+	}
+	if len(policies) <= 0 {
+		return nil
+	}
+	// @step: order the policies so the violations are reported in a consistent order
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+
+	// @check the policies are valid, we refuse to continue on a broken policy
+	for _, x := range policies {
+		if errs := x.IsValid(); len(errs) > 0 {
+			return fmt.Errorf("cloud policy: %s is invalid: %s", x.Name, utils.GetErrors(errs))
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"namespace": resource.Namespace,
+		"policies":  len(policies),
+		"resource":  resource.Name,
+	}).Debug("evaluating the rendered template against the cloud policies")
+
+	return policy.Evaluate(policies, content)
+}
+
+// policyCondition returns the policy violation condition from the result of evaluating the cloud policies;
+// a failure to evaluate the policies is neither a violation nor a pass
+func policyCondition(err error) *apiv1.Condition {
+	switch {
+	case err == nil:
+		return &apiv1.Condition{
+			Status: apiv1.ConditionFalse,
+			Type:   apiv1.ConditionPolicyViolation,
+		}
+	case policy.IsViolation(err):
+		return &apiv1.Condition{
+			Message: err.Error(),
+			Reason:  apiv1.ConditionPolicyViolation,
+			Status:  apiv1.ConditionTrue,
+			Type:    apiv1.ConditionPolicyViolation,
+		}
+	}
+
+	return &apiv1.Condition{
+		Message: err.Error(),
+		Reason:  getErrorReason(err),
+		Status:  apiv1.ConditionUnknown,
+		Type:    apiv1.ConditionPolicyViolation,
+	}
+}
+
+// recordPolicyViolations raises an event on the resource for each of the policy violations
+func (c *controller) recordPolicyViolations(resource *apiv1.CloudResource, err error) {
+	if c.options.Record == nil || !policy.IsViolation(err) {
+		return
+	}
+	for _, x := range err.(*policy.ViolationError).Violations {
+		c.options.Record.Eventf(resource, core.EventTypeWarning, apiv1.ConditionPolicyViolation, "%s", x.String())
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// retainedSyncInterval is the interval the retained stacks are refreshed from the cloud provider
+const retainedSyncInterval = time.Minute * 5
+
+// retainedStacks is a cache of the stacks retained after their resource was deleted; they are
+// still active and count towards the namespace quotas until removed by the cleanup
+type retainedStacks struct {
+	sync.RWMutex
+	// stacks is a map of stack name to the retained stack
+	stacks map[string]*models.Stack
+}
+
+// newRetainedStacks returns an empty cache of retained stacks
+func newRetainedStacks() *retainedStacks {
+	return &retainedStacks{stacks: make(map[string]*models.Stack, 0)}
+}
+
+// add records a stack retained after the deletion of its resource
+func (r *retainedStacks) add(stack *models.Stack) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.stacks[stack.Name] = stack
+}
+
+// replace replaces the cache with the retained stacks from the list of stacks
+func (r *retainedStacks) replace(stacks []*models.Stack) {
+	list := make(map[string]*models.Stack, 0)
+	for _, x := range stacks {
+		if x.HasDeleteTag() {
+			list[x.Name] = x
+		}
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.stacks = list
+}
+
+// list returns the retained stacks in the namespace
+func (r *retainedStacks) list(namespace string) []*models.Stack {
+	r.RLock()
+	defer r.RUnlock()
+
+	var list []*models.Stack
+	for _, x := range r.stacks {
+		if x.Namespace == namespace {
+			list = append(list, x)
+		}
+	}
+
+	return list
+}
+
+// syncRetainedStacks is responsible for refreshing the retained stacks from the cloud provider
+func (c *controller) syncRetainedStacks(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	stacks, err := c.options.Cloud.List(ctx, &models.ListOptions{})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("unable to refresh the retained stacks, retaining the previous")
+
+		return
+	}
+	c.retained.replace(stacks)
+}
+
+// quotaExceededError is returned when the resource is not permitted by the namespace quotas
+type quotaExceededError struct {
+	reasons []string
+}
+
+// Error returns the reasons the quota was exceeded
+func (e *quotaExceededError) Error() string {
+	return fmt.Sprintf("namespace quota exceeded: %s", strings.Join(e.reasons, "; "))
+}
+
+// isQuotaExceeded checks if the error is a quota error
+func isQuotaExceeded(err error) bool {
+	_, ok := err.(*quotaExceededError)
+
+	return ok
+}
+
+// checkCloudQuotas is responsible for enforcing the namespace quotas on the resource; the count
+// limits are only applied when the stack is being created.
+//
+// The usage is taken from the cloud resources in the informer cache and the cached retained stacks rather
+// than the cloud, a resource being counted against the resources created before it. As the resources are
+// ordered by creation, concurrent creates in a namespace cannot both pass the check on the same count: the
+// younger of the two always counts the older, at worst it is denied until the informer has caught up with
+// the older one. A resource which was denied and never had a stack is not counted.
+func (c *controller) checkCloudQuotas(resource *apiv1.CloudResource, creating bool) error {
+	quotas, err := utils.FindCloudQuotas(c.options.ResourceClient, resource.Namespace)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the cloud quotas: %s", err)
+	}
+	if len(quotas) <= 0 {
+		return nil
+	}
+
+	// @check the quotas are valid, we refuse to continue on a broken quota
+	for _, x := range quotas {
+		if errs := x.IsValid(); len(errs) > 0 {
+			return fmt.Errorf("cloud quota: %s is invalid: %s", x.Name, utils.GetErrors(errs))
+		}
+	}
+
+	// @step: calculate the current usage of the namespace and the usage preceding the resource
+	var all, preceding []*apiv1.CloudResource
+	for _, obj := range c.informer.GetStore().List() {
+		x, ok := obj.(*apiv1.CloudResource)
+		if !ok || x.Namespace != resource.Namespace {
+			continue
+		}
+		if x.Name != resource.Name && !c.hasStack(x) {
+			continue
+		}
+		all = append(all, x)
+		if isCreatedBefore(x, resource) {
+			preceding = append(preceding, x)
+		}
+	}
+	retained := c.retained.list(resource.Namespace)
+	usage := getQuotaUsage(all, retained)
+
+	log.WithFields(log.Fields{
+		"namespace": resource.Namespace,
+		"preceding": len(preceding),
+		"quotas":    len(quotas),
+		"resource":  resource.Name,
+		"stacks":    usage.Stacks,
+	}).Debug("checking the resource against the namespace quotas")
+
+	var reasons []string
+	for _, x := range quotas {
+		reasons = append(reasons, checkCloudQuota(&x, getQuotaUsage(preceding, retained), resource.Spec.TemplateName, creating)...)
+
+		// @check the quota is only updated when the usage has changed
+		if x.Status.Stacks == usage.Stacks && equality.Semantic.DeepEqual(x.Status.Templates, usage.Templates) {
+			continue
+		}
+		usage.LastUpdated = metav1.Now()
+		if err := utils.UpdateCloudQuotaStatus(c.options.ResourceClient, x.Name, x.Namespace, usage); err != nil {
+			log.WithFields(log.Fields{
+				"error":     err.Error(),
+				"namespace": x.Namespace,
+				"quota":     x.Name,
+			}).Warn("failed to update the cloud quota status")
+		}
+	}
+	if len(reasons) > 0 {
+		return &quotaExceededError{reasons: reasons}
+	}
+
+	return nil
+}
+
+// checkCloudQuota checks the template against a single quota and returns any breaches
+func checkCloudQuota(quota *apiv1.CloudQuota, usage apiv1.CloudQuotaStatus, template string, creating bool) []string {
+	var reasons []string
+
+	if !quota.IsTemplateAllowed(template) {
+		reasons = append(reasons, fmt.Sprintf("quota: %s does not permit template: %s", quota.Name, template))
+	}
+	if !creating {
+		return reasons
+	}
+	if quota.Spec.MaxStacks != nil && usage.Stacks+1 > *quota.Spec.MaxStacks {
+		reasons = append(reasons, fmt.Sprintf("quota: %s permits a maximum of %d stacks, %d in use",
+			quota.Name, *quota.Spec.MaxStacks, usage.Stacks))
+	}
+	if limit, found := quota.GetTemplateLimit(template); found {
+		var count int
+		for _, x := range usage.Templates {
+			if x.Name == template {
+				count = x.Count
+			}
+		}
+		if count+1 > limit {
+			reasons = append(reasons, fmt.Sprintf("quota: %s permits a maximum of %d resources from template: %s, %d in use",
+				quota.Name, limit, template, count))
+		}
+	}
+
+	return reasons
+}
+
+// getQuotaUsage calculates the usage from the cloud resources, each resource having a stack, and the
+// stacks retained after their resource was deleted; a retained stack taken over by a resource of the
+// same name is only counted once
+func getQuotaUsage(resources []*apiv1.CloudResource, retained []*models.Stack) apiv1.CloudQuotaStatus {
+	var usage apiv1.CloudQuotaStatus
+
+	counts := make(map[string]int, 0)
+	names := make(map[string]bool, 0)
+	for _, x := range resources {
+		usage.Stacks++
+		counts[x.Spec.TemplateName]++
+		names[models.GetStackName(x.Name, x.Namespace)] = true
+	}
+	for _, x := range retained {
+		if names[x.Name] {
+			continue
+		}
+		usage.Stacks++
+		counts[x.Spec.Template]++
+	}
+	for k, v := range counts {
+		usage.Templates = append(usage.Templates, apiv1.TemplateUsage{Name: k, Count: v})
+	}
+	sort.Slice(usage.Templates, func(i, j int) bool {
+		return usage.Templates[i].Name < usage.Templates[j].Name
+	})
+
+	return usage
+}
+
+// hasStack checks if the resource has, or is in the process of creating, a stack; a resource denied
+// before a stack was created has a failed status without a stack
+func (c *controller) hasStack(resource *apiv1.CloudResource) bool {
+	obj, found, err := c.statuses.GetStore().GetByKey(resource.Namespace + "/" + resource.Name)
+	if err != nil || !found {
+		return true
+	}
+	status, ok := obj.(*apiv1.CloudStatus)
+	if !ok {
+		return true
+	}
+
+	return status.Stack != nil || status.Status != models.PhaseFailed
+}
+
+// isCreatedBefore checks if the resource was created before the other, the name breaking a tie
+func isCreatedBefore(resource, other *apiv1.CloudResource) bool {
+	if !resource.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return resource.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+
+	return resource.Name < other.Name
+}
+
+// recordQuotaExceeded raises an event on the resource when the quota has been exceeded
+func (c *controller) recordQuotaExceeded(resource *apiv1.CloudResource, err error) {
+	if c.options.Record == nil || !isQuotaExceeded(err) {
+		return
+	}
+	c.options.Record.Event(resource, core.EventTypeWarning, apiv1.ConditionQuotaExceeded, err.Error())
+}
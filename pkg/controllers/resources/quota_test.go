@@ -0,0 +1,144 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kfake "k8s.io/client-go/kubernetes/fake"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/client/clientset/versioned/fake"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+func TestCheckCloudQuotasConcurrentCreates(t *testing.T) {
+	limit := 1
+	resources := fake.NewSimpleClientset(&apiv1.CloudQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "test"},
+		Spec:       apiv1.CloudQuotaSpec{MaxStacks: &limit},
+	})
+	c := newTestResourceController(t, nil, kfake.NewSimpleClientset(), resources)
+
+	created := metav1.NewTime(time.Now())
+	older := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test", CreationTimestamp: created},
+		Spec:       apiv1.CloudResourceSpec{TemplateName: "bucket"},
+	}
+	younger := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test", CreationTimestamp: created},
+		Spec:       apiv1.CloudResourceSpec{TemplateName: "bucket"},
+	}
+	require.NoError(t, c.informer.GetStore().Add(older))
+	require.NoError(t, c.informer.GetStore().Add(younger))
+
+	// @check only one of the resources created together is permitted, whichever is checked first
+	assert.True(t, isQuotaExceeded(c.checkCloudQuotas(younger, true)))
+	assert.NoError(t, c.checkCloudQuotas(older, true))
+
+	quota, err := resources.CloudV1().CloudQuotas("test").Get("quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, quota.Status.Stacks)
+	assert.Equal(t, []apiv1.TemplateUsage{{Name: "bucket", Count: 2}}, quota.Status.Templates)
+
+	// @check the quota is not rewritten when the usage has not changed
+	updated := quota.Status.LastUpdated
+	require.NoError(t, c.checkCloudQuotas(older, true))
+	quota, err = resources.CloudV1().CloudQuotas("test").Get("quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, updated, quota.Status.LastUpdated)
+}
+
+func TestCheckCloudQuotasRetainedStacks(t *testing.T) {
+	limit := 1
+	resources := fake.NewSimpleClientset(&apiv1.CloudQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "test"},
+		Spec:       apiv1.CloudQuotaSpec{MaxStacks: &limit},
+	})
+	c := newTestResourceController(t, nil, kfake.NewSimpleClientset(), resources)
+
+	resource := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test", CreationTimestamp: metav1.Now()},
+		Spec:       apiv1.CloudResourceSpec{TemplateName: "bucket"},
+	}
+	require.NoError(t, c.informer.GetStore().Add(resource))
+
+	c.retained.replace([]*models.Stack{
+		{
+			Name:      models.GetStackName("deleted", "test"),
+			Namespace: "test",
+			Spec: models.StackSpec{
+				Template: "bucket",
+				Tags:     map[string]string{models.DeletionTimeTag: "1"},
+			},
+		},
+		{
+			Name:      models.GetStackName("other", "test"),
+			Namespace: "test",
+			Spec:      models.StackSpec{Template: "bucket"},
+		},
+	})
+
+	// @check the stack retained after its resource was deleted is counted
+	assert.True(t, isQuotaExceeded(c.checkCloudQuotas(resource, true)))
+	quota, err := resources.CloudV1().CloudQuotas("test").Get("quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, quota.Status.Stacks)
+
+	// @check a resource taking over the retained stack is only counted once
+	taken := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "test", CreationTimestamp: resource.CreationTimestamp},
+		Spec:       apiv1.CloudResourceSpec{TemplateName: "bucket"},
+	}
+	require.NoError(t, c.informer.GetStore().Add(taken))
+	assert.Equal(t, 2, getQuotaUsage([]*apiv1.CloudResource{resource, taken}, c.retained.list("test")).Stacks)
+}
+
+func TestCheckCloudQuotasDeniedResources(t *testing.T) {
+	limit := 1
+	resources := fake.NewSimpleClientset(&apiv1.CloudQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "test"},
+		Spec:       apiv1.CloudQuotaSpec{MaxStacks: &limit},
+	})
+	c := newTestResourceController(t, nil, kfake.NewSimpleClientset(), resources)
+
+	created := metav1.NewTime(time.Now())
+	denied := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test", CreationTimestamp: created},
+		Spec:       apiv1.CloudResourceSpec{TemplateName: "bucket"},
+	}
+	resource := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test", CreationTimestamp: created},
+		Spec:       apiv1.CloudResourceSpec{TemplateName: "bucket"},
+	}
+	require.NoError(t, c.informer.GetStore().Add(denied))
+	require.NoError(t, c.informer.GetStore().Add(resource))
+	require.NoError(t, c.statuses.GetStore().Add(&apiv1.CloudStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"},
+		Status:     models.PhaseFailed,
+	}))
+
+	// @check the resource denied before a stack was created does not count towards the usage
+	assert.NoError(t, c.checkCloudQuotas(resource, true))
+	quota, err := resources.CloudV1().CloudQuotas("test").Get("quota", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, quota.Status.Stacks)
+}
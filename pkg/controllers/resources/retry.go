@@ -0,0 +1,80 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"time"
+
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/policy"
+)
+
+const (
+	// retryNever indicates the reconcile should not be retried
+	retryNever = time.Duration(-1)
+	// retryRateLimited indicates the reconcile should be retried by the rate limiter of the queue
+	retryRateLimited = time.Duration(0)
+	// retrySlowInterval is the interval a reconcile is retried when waiting on a change outside the
+	// controller, i.e. permissions being granted or a quota raised
+	retrySlowInterval = time.Minute * 5
+	// throttledBaseDelay is the initial delay of the backoff when the cloud provider throttles us
+	throttledBaseDelay = time.Second * 30
+	// throttledMaxDelay is the maximum delay of the backoff when the cloud provider throttles us
+	throttledMaxDelay = time.Minute * 10
+)
+
+// classifyError returns the class of the error from a reconcile; the policy and quota errors are
+// raised by the controller itself, everything else is classified by the provider
+func classifyError(err error) string {
+	switch {
+	case policy.IsViolation(err):
+		return models.ErrorClassInvalidTemplate
+	case isQuotaExceeded(err):
+		return models.ErrorClassQuotaExceeded
+	}
+
+	return models.GetErrorClass(err)
+}
+
+// getErrorReason returns a stable CamelCase reason for the error from a reconcile
+func getErrorReason(err error) string {
+	if class := classifyError(err); class != models.GetErrorClass(err) {
+		return class
+	}
+
+	return models.GetErrorReason(err)
+}
+
+// retryAfter returns the delay before a failed reconcile of the key is retried; an invalid template or a
+// failed stack is never retried as the resource or template has to change, which requeues it anyway
+func (c *controller) retryAfter(key interface{}, class string) time.Duration {
+	switch class {
+	case models.ErrorClassInvalidTemplate, models.ErrorClassStackFailed:
+		return retryNever
+	case models.ErrorClassThrottled:
+		return c.throttle.When(key)
+	case models.ErrorClassConflict:
+		return operationPollInterval
+	case models.ErrorClassQuotaExceeded, models.ErrorClassUnauthorized:
+		return retrySlowInterval
+	}
+	if c.queue.NumRequeues(key) >= c.options.MaxRetries {
+		return retryNever
+	}
+
+	return retryRateLimited
+}
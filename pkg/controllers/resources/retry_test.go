@@ -0,0 +1,116 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/policy"
+)
+
+func TestRetryAfter(t *testing.T) {
+	c := newTestResourceController(t, nil, nil, nil)
+	c.options.MaxRetries = 1
+	key := "test/bucket"
+
+	assert.Equal(t, retryNever, c.retryAfter(key, models.ErrorClassInvalidTemplate))
+	assert.Equal(t, retryNever, c.retryAfter(key, models.ErrorClassStackFailed))
+	assert.Equal(t, operationPollInterval, c.retryAfter(key, models.ErrorClassConflict))
+	assert.Equal(t, retrySlowInterval, c.retryAfter(key, models.ErrorClassUnauthorized))
+
+	// @check the throttled resources backoff
+	assert.Equal(t, throttledBaseDelay, c.retryAfter(key, models.ErrorClassThrottled))
+	assert.Equal(t, throttledBaseDelay*2, c.retryAfter(key, models.ErrorClassThrottled))
+
+	// @check the transient errors are dropped after the max retries
+	assert.Equal(t, retryRateLimited, c.retryAfter(key, models.ErrorClassTransient))
+	c.queue.AddRateLimited(key)
+	assert.Equal(t, retryNever, c.retryAfter(key, models.ErrorClassTransient))
+}
+
+func TestGetErrorReason(t *testing.T) {
+	assert.Equal(t, "ReconcileFailed", getErrorReason(errors.New("unknown")))
+	assert.Equal(t, models.ErrorClassQuotaExceeded, getErrorReason(&quotaExceededError{}))
+	assert.Equal(t, models.ErrorClassInvalidTemplate, getErrorReason(&policy.ViolationError{}))
+	assert.Equal(t, models.ErrorClassThrottled, getErrorReason(
+		models.WrapError(models.NewError(models.ErrorClassThrottled, "Throttling", errors.New("rate exceeded")), "unable to get stack")))
+}
+
+func TestPolicyCondition(t *testing.T) {
+	assert.Equal(t, apiv1.ConditionFalse, policyCondition(nil).Status)
+	assert.Equal(t, apiv1.ConditionTrue, policyCondition(&policy.ViolationError{}).Status)
+	assert.Equal(t, apiv1.ConditionUnknown, policyCondition(errors.New("unable to evaluate")).Status)
+}
+
+func TestPolicyConditionRetained(t *testing.T) {
+	client, resources := newTestClients()
+	c := newTestResourceController(t, newTestCloud(t), client, resources)
+	resource := &apiv1.CloudResource{ObjectMeta: metav1.ObjectMeta{Name: "bucket", Namespace: "test"}}
+
+	getCondition := func() string {
+		status, err := resources.CloudV1().CloudStatuses("test").Get("bucket", metav1.GetOptions{})
+		require.NoError(t, err)
+		condition, found := status.GetCondition(apiv1.ConditionPolicyViolation)
+		require.True(t, found)
+
+		return condition.Status
+	}
+
+	// @check the condition is only changed when the policies were evaluated
+	violation := policyCondition(&policy.ViolationError{})
+	require.NoError(t, c.updateCloudStatus(context.TODO(), nil, &policy.ViolationError{}, resource, nil, nil, violation))
+	assert.Equal(t, apiv1.ConditionTrue, getCondition())
+
+	require.NoError(t, c.updateCloudStatus(context.TODO(), nil, errors.New("throttled"), resource, nil, nil, nil))
+	assert.Equal(t, apiv1.ConditionTrue, getCondition())
+
+	require.NoError(t, c.updateCloudStatus(context.TODO(), nil, errors.New("throttled"), resource, nil, nil, policyCondition(nil)))
+	assert.Equal(t, apiv1.ConditionFalse, getCondition())
+}
+
+func TestDeprecationCondition(t *testing.T) {
+	client, resources := newTestClients()
+	c := newTestResourceController(t, newTestCloud(t), client, resources)
+	resource := &apiv1.CloudResource{ObjectMeta: metav1.ObjectMeta{Name: "bucket", Namespace: "test"}}
+	template := &apiv1.CloudTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "bucket"},
+		Spec: apiv1.TemplateSpec{
+			Deprecated: true,
+			Successor:  &apiv1.TemplateSuccessor{Name: "bucket.v2"},
+		},
+	}
+
+	// @check the deprecation of the template is recorded on the status of the resource
+	require.NoError(t, c.updateCloudStatus(context.TODO(), nil, errors.New("throttled"), resource, nil, nil, nil, c.recordDeprecation(resource, template)))
+	status, err := resources.CloudV1().CloudStatuses("test").Get("bucket", metav1.GetOptions{})
+	require.NoError(t, err)
+	condition, found := status.GetCondition(apiv1.ConditionTemplateDeprecated)
+	require.True(t, found)
+	assert.Equal(t, apiv1.ConditionTrue, condition.Status)
+	assert.Equal(t, template.GetDeprecationWarning(), condition.Message)
+
+	template.Spec.Deprecated = false
+	assert.Equal(t, apiv1.ConditionFalse, c.recordDeprecation(resource, template).Status)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// getSupersedePolicy returns the policy on the resource changing while an operation is in progress
+func (c *controller) getSupersedePolicy(resource *apiv1.CloudResource) string {
+	switch {
+	case resource.Spec.SupersedePolicy != "":
+		return resource.Spec.SupersedePolicy
+	case c.config.SupersedePolicy != "":
+		return c.config.SupersedePolicy
+	}
+
+	return apiv1.SupersedeCoalesce
+}
+
+// supersedeOperation is responsible for handling the resource changing while an operation is in progress
+// on the stack. The update is either cancelled or left to complete; either way only the newest
+// specification of the resource is applied once the stack is no longer in progress
+func (c *controller) supersedeOperation(ctx context.Context, stackname string, resource *apiv1.CloudResource, status *apiv1.CloudStatus) error {
+	operation := status.Operation
+	checksum := models.GetResourceChecksum(resource)
+
+	// @check if the resource has changed since the operation was started or last superseded
+	if operation.Checksum == "" || operation.Checksum == checksum {
+		return nil
+	}
+	if operation.Superseded != nil && operation.Superseded.Checksum == checksum {
+		return nil
+	}
+
+	superseded := &apiv1.StackSupersede{
+		Checksum:           checksum,
+		Count:              1,
+		LastTransitionTime: metav1.Now(),
+		Policy:             c.getSupersedePolicy(resource),
+	}
+	// @check an operation is only cancelled once, further changes are coalesced into the next update
+	if previous := operation.Superseded; previous != nil {
+		superseded.Count = previous.Count + 1
+		superseded.Policy = previous.Policy
+	} else if superseded.Policy == apiv1.SupersedeCancel {
+		switch err := c.options.Cloud.Cancel(ctx, stackname); err {
+		case nil:
+		case models.ErrNotCancellable:
+			log.WithFields(log.Fields{
+				"namespace": resource.Namespace,
+				"operation": operation.Type,
+				"resource":  resource.Name,
+			}).Warn("stack operation cannot be cancelled, coalescing the change into the next update")
+
+			superseded.Policy = apiv1.SupersedeCoalesce
+		default:
+			return fmt.Errorf("unable to cancel the stack operation: %s", err)
+		}
+	}
+	operation.Superseded = superseded
+
+	log.WithFields(log.Fields{
+		"changes":   superseded.Count,
+		"checksum":  checksum,
+		"id":        operation.ID,
+		"namespace": resource.Namespace,
+		"operation": operation.Type,
+		"policy":    superseded.Policy,
+		"resource":  resource.Name,
+	}).Info("resource has changed while the stack operation is in progress")
+
+	supersededCounter.WithLabelValues(superseded.Policy).Inc()
+
+	if err := utils.UpdateCloudStatus(c.options.ResourceClient, status); err != nil {
+		return fmt.Errorf("failed to update the cloud status for stack: (%s/%s), error: %s", resource.Namespace, resource.Name, err)
+	}
+
+	return nil
+}
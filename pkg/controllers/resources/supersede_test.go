@@ -0,0 +1,86 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+func TestSupersedeOperation(t *testing.T) {
+	cases := []struct {
+		Policy    string
+		Cancel    error
+		Cancelled int
+		Expected  string
+	}{
+		{Policy: apiv1.SupersedeCancel, Cancelled: 1, Expected: apiv1.SupersedeCancel},
+		{Policy: apiv1.SupersedeCancel, Cancel: models.ErrNotCancellable, Cancelled: 1, Expected: apiv1.SupersedeCoalesce},
+		{Policy: apiv1.SupersedeCoalesce, Expected: apiv1.SupersedeCoalesce},
+	}
+	for _, x := range cases {
+		cloud := &slowCloud{testCloud: newTestCloud(t), cancel: x.Cancel}
+		client, resources := newTestClients()
+		c := newTestResourceController(t, cloud, client, resources)
+
+		resource, err := utils.FindCloudResource(resources, "bucket", "test")
+		require.NoError(t, err)
+		resource.Spec.SupersedePolicy = x.Policy
+
+		// @step: start the operation and change the resource twice while it is in progress
+		require.NoError(t, c.updated(resource))
+		for _, value := range []string{"a", "b"} {
+			v := value
+			resource.Spec.Parameters = []apiv1.Parameter{{Name: "name", Value: &v}}
+			require.NoError(t, c.updated(resource))
+		}
+
+		status, err := resources.CloudV1().CloudStatuses("test").Get("bucket", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, status.Operation)
+		superseded := status.Operation.Superseded
+		require.NotNil(t, superseded, "case: %s", x.Policy)
+		assert.Equal(t, x.Expected, superseded.Policy, "case: %s", x.Policy)
+		assert.Equal(t, int32(2), superseded.Count, "case: %s", x.Policy)
+		assert.Equal(t, models.GetResourceChecksum(resource), superseded.Checksum, "case: %s", x.Policy)
+		assert.Equal(t, x.Cancelled, cloud.cancelled, "case: %s", x.Policy)
+	}
+}
+
+func TestSupersedeOperationUnchanged(t *testing.T) {
+	cloud := &slowCloud{testCloud: newTestCloud(t)}
+	client, resources := newTestClients()
+	c := newTestResourceController(t, cloud, client, resources)
+
+	resource, err := utils.FindCloudResource(resources, "bucket", "test")
+	require.NoError(t, err)
+	require.NoError(t, c.updated(resource))
+	require.NoError(t, c.updated(resource))
+
+	status, err := resources.CloudV1().CloudStatuses("test").Get("bucket", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, status.Operation)
+	assert.Equal(t, models.GetResourceChecksum(resource), status.Operation.Checksum)
+	assert.Nil(t, status.Operation.Superseded)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// getStackTimeout returns the timeout for an operation on the stack, the resource taking precedence over
+// the template and the template over the controller default; zero indicates no timeout
+func (c *controller) getStackTimeout(resource *apiv1.CloudResource, template *apiv1.CloudTemplate) time.Duration {
+	switch {
+	case resource != nil && resource.Spec.Timeout != nil:
+		return resource.Spec.Timeout.Duration
+	case template != nil && template.Spec.Timeout != nil:
+		return template.Spec.Timeout.Duration
+	}
+
+	return c.config.StackTimeout
+}
+
+// getTimeoutAction returns the action to take on an operation which has exceeded the timeout
+func (c *controller) getTimeoutAction(resource *apiv1.CloudResource) string {
+	switch {
+	case resource.Spec.TimeoutAction != "":
+		return resource.Spec.TimeoutAction
+	case c.config.StackTimeoutAction != "":
+		return c.config.StackTimeoutAction
+	}
+
+	return apiv1.TimeoutActionCancel
+}
+
+// timeoutOperation is responsible for taking the timeout action on an operation which has exceeded the
+// timeout and recording it in the status; an operation which cannot be cancelled is marked as failed
+func (c *controller) timeoutOperation(ctx context.Context, stackname string, resource *apiv1.CloudResource, status *apiv1.CloudStatus) error {
+	operation := status.Operation
+	action := c.getTimeoutAction(resource)
+
+	log.WithFields(log.Fields{
+		"action":    action,
+		"id":        operation.ID,
+		"namespace": resource.Namespace,
+		"operation": operation.Type,
+		"resource":  resource.Name,
+		"timeout":   operation.Timeout.Duration.String(),
+	}).Warn("stack operation has exceeded the timeout")
+
+	// @step: attempt to cancel the operation on the stack
+	if action == apiv1.TimeoutActionCancel {
+		switch err := c.options.Cloud.Cancel(ctx, stackname); err {
+		case nil:
+		case models.ErrNotCancellable:
+			log.WithFields(log.Fields{
+				"namespace": resource.Namespace,
+				"operation": operation.Type,
+				"resource":  resource.Name,
+			}).Warn("stack operation cannot be cancelled, marking the resource as failed")
+
+			action = apiv1.TimeoutActionFail
+		default:
+			return fmt.Errorf("unable to cancel the stack operation: %s", err)
+		}
+	}
+	operationTimeoutsCounter.WithLabelValues(operation.Type, action).Inc()
+
+	message := fmt.Sprintf("stack operation: %s did not complete within %s", operation.Type, operation.Timeout.Duration)
+
+	operation.TimeoutAction = action
+	status.SetCondition(apiv1.Condition{
+		Message: message,
+		Reason:  action,
+		Status:  apiv1.ConditionTrue,
+		Type:    apiv1.ConditionTimedOut,
+	})
+	if action == apiv1.TimeoutActionFail {
+		status.Status = models.PhaseFailed
+		status.Message = message
+		status.Reason = apiv1.ConditionTimedOut
+	}
+	if err := utils.UpdateCloudStatus(c.options.ResourceClient, status); err != nil {
+		return fmt.Errorf("failed to update the cloud status for stack: (%s/%s), error: %s", resource.Namespace, resource.Name, err)
+	}
+
+	// @check a failed operation is no longer polled, the resync picks up the stack once complete
+	if action != apiv1.TimeoutActionFail {
+		c.requeue(resource, operationPollInterval)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/controllers/api"
+	"github.com/gambol99/resources/pkg/models"
+	"github.com/gambol99/resources/pkg/utils"
+)
+
+// slowCloud is the null provider whose operations never complete
+type slowCloud struct {
+	*testCloud
+	cancel    error
+	cancelled int
+}
+
+func (s *slowCloud) OperationStatus(context.Context, string, *apiv1.StackOperation) (*models.StackStatus, error) {
+	return &models.StackStatus{Phase: models.PhaseUpdating}, nil
+}
+
+func (s *slowCloud) Cancel(context.Context, string) error {
+	s.cancelled++
+
+	return s.cancel
+}
+
+func TestOperationTimeout(t *testing.T) {
+	cases := []struct {
+		Action    string
+		Cancel    error
+		Cancelled int
+		Expected  string
+		Failed    bool
+	}{
+		{Action: apiv1.TimeoutActionCancel, Cancelled: 1, Expected: apiv1.TimeoutActionCancel},
+		{Action: apiv1.TimeoutActionCancel, Cancel: models.ErrNotCancellable, Cancelled: 1, Expected: apiv1.TimeoutActionFail, Failed: true},
+		{Action: apiv1.TimeoutActionLeave, Expected: apiv1.TimeoutActionLeave},
+		{Action: apiv1.TimeoutActionFail, Expected: apiv1.TimeoutActionFail, Failed: true},
+	}
+	for _, x := range cases {
+		cloud := &slowCloud{testCloud: newTestCloud(t), cancel: x.Cancel}
+		client, resources := newTestClients()
+		c := newTestResourceController(t, cloud, client, resources)
+
+		resource, err := utils.FindCloudResource(resources, "bucket", "test")
+		require.NoError(t, err)
+		resource.Spec.Timeout = &metav1.Duration{Duration: time.Nanosecond}
+		resource.Spec.TimeoutAction = x.Action
+
+		// @step: the first pass starts the operation and the second finds it has timed out
+		require.NoError(t, c.updated(resource))
+		require.NoError(t, c.updated(resource))
+
+		status, err := resources.CloudV1().CloudStatuses("test").Get("bucket", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, status.Operation)
+		assert.Equal(t, x.Expected, status.Operation.TimeoutAction, "case: %s", x.Action)
+		assert.Equal(t, x.Cancelled, cloud.cancelled, "case: %s", x.Action)
+		assert.Equal(t, x.Failed, status.Status == models.PhaseFailed, "case: %s", x.Action)
+
+		condition, found := status.GetCondition(apiv1.ConditionTimedOut)
+		require.True(t, found)
+		assert.Equal(t, apiv1.ConditionTrue, condition.Status)
+
+		// @check the action is only taken once
+		require.NoError(t, c.updated(resource))
+		assert.Equal(t, x.Cancelled, cloud.cancelled, "case: %s", x.Action)
+	}
+}
+
+func TestGetStackTimeout(t *testing.T) {
+	c := &controller{config: &api.Config{StackTimeout: time.Hour}}
+	resource := &apiv1.CloudResource{}
+	template := &apiv1.CloudTemplate{}
+
+	assert.Equal(t, time.Hour, c.getStackTimeout(resource, template))
+	template.Spec.Timeout = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, c.getStackTimeout(resource, template))
+	resource.Spec.Timeout = &metav1.Duration{Duration: time.Second}
+	assert.Equal(t, time.Second, c.getStackTimeout(resource, template))
+	assert.Equal(t, time.Minute, c.getStackTimeout(nil, template))
+}
+
+// deleteCloud is the null provider recording the deadline of the stack deletion
+type deleteCloud struct {
+	*testCloud
+	deadline time.Time
+}
+
+func (d *deleteCloud) Get(ctx context.Context, name string, options *models.GetOptions) (*models.Stack, error) {
+	return &models.Stack{Name: name, Spec: models.StackSpec{Template: "bucket"}}, nil
+}
+
+func (d *deleteCloud) Delete(ctx context.Context, name string, options *models.DeleteOptions) error {
+	d.deadline, _ = ctx.Deadline()
+
+	return nil
+}
+
+func TestDeleteStackTimeout(t *testing.T) {
+	client, resources := newTestClients()
+	template, err := resources.CloudV1().CloudTemplates().Get("bucket", metav1.GetOptions{})
+	require.NoError(t, err)
+	template.Spec.Timeout = &metav1.Duration{Duration: time.Hour * 3}
+	_, err = resources.CloudV1().CloudTemplates().Update(template)
+	require.NoError(t, err)
+
+	cloud := &deleteCloud{testCloud: newTestCloud(t)}
+	c := newTestResourceController(t, cloud, client, resources)
+
+	// @check the deletion of the stack is bounded by the stack timeout of the template
+	require.NoError(t, c.deleted("bucket", "test"))
+	require.False(t, cloud.deadline.IsZero())
+	assert.WithinDuration(t, time.Now().Add(time.Hour*3), cloud.deadline, time.Minute)
+}
@@ -18,9 +18,7 @@ package resources
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,7 +30,7 @@ import (
 
 // updated is responsible for updating / creating a resoruce
 func (c *controller) updated(resource *apiv1.CloudResource) error {
-	stackname := getStackName(resource.Name, resource.Namespace)
+	stackname := models.GetStackName(resource.Name, resource.Namespace)
 
 	// @step: check if the resource requires updating from the checksum
 
@@ -41,23 +39,74 @@ func (c *controller) updated(resource *apiv1.CloudResource) error {
 	if err != nil {
 		return fmt.Errorf("unable to retrieve cloud template: %s, error: %s", resource.Spec.TemplateName, err)
 	}
+	// @check if the resource has opted into migrating from the deprecated template
+	if models.WantsMigration(resource, template) {
+		return c.migrate(resource, template)
+	}
+	deprecation := c.recordDeprecation(resource, template)
+
+	// @step: resolve the base templates and fragments the template is composed from
+	resolved, err := utils.ResolveCloudTemplate(c.options.ResourceClient, c.options.Content, template)
+	if err != nil {
+		return fmt.Errorf("unable to resolve cloud template: %s, error: %s", resource.Spec.TemplateName, err)
+	}
+	template = resolved.Template
 
-	// @step: lets use a default 30 minutes for now
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*30)
+	// @step: the cloud calls never wait on the stack, so only need a short timeout
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
 	defer cancel()
 
+	// @check if an operation is still in progress on the stack, if so we come back later
+	if inprogress, err := c.checkOperation(ctx, stackname, resource); err != nil {
+		return err
+	} else if inprogress {
+		return nil
+	}
+
 	// @step: attempt to update the resource
-	stack, result := c.updateCloudResource(ctx, stackname, resource, template)
+	stack, operation, evaluation, result := c.updateCloudResource(ctx, stackname, resource, resolved)
 	if result != nil {
 		log.WithFields(log.Fields{
 			"error":     result.Error(),
 			"resource":  resource.Name,
 			"namespace": resource.Namespace,
 		}).Error("failed to update / create the cloud resource")
+
+		c.recordPolicyViolations(resource, result)
+		c.recordQuotaExceeded(resource, result)
+	}
+
+	// @check if an operation has been started, we record it and poll the stack until it completes
+	if result == nil && operation != nil {
+		log.WithFields(log.Fields{
+			"id":        operation.ID,
+			"namespace": resource.Namespace,
+			"operation": operation.Type,
+			"resource":  resource.Name,
+		}).Info("waiting on the stack operation to complete")
+
+		if err := c.updateCloudStatus(ctx, stack, nil, resource, nil, operation, evaluation, deprecation); err != nil {
+			return fmt.Errorf("failed to update the cloud status for stack: (%s/%s), error: %s", resource.Namespace, resource.Name, err)
+		}
+		c.requeue(resource, operationPollInterval)
+
+		return nil
+	}
+
+	// @step: compare the deployed stack with the template as it renders now
+	var diff *apiv1.StackDiff
+	if result == nil && stack != nil {
+		if diff, err = c.diffCloudResource(ctx, stackname, resource, resolved); err != nil {
+			log.WithFields(log.Fields{
+				"error":     err.Error(),
+				"resource":  resource.Name,
+				"namespace": resource.Namespace,
+			}).Warn("unable to compare the stack with the rendered template")
+		}
 	}
 
 	// @step: update the status of the status of the resource
-	if err := c.updateCloudStatus(ctx, stack, result, resource); err != nil {
+	if err := c.updateCloudStatus(ctx, stack, result, resource, diff, nil, evaluation, deprecation); err != nil {
 		log.WithFields(log.Fields{
 			"error":     err.Error(),
 			"resource":  resource.Name,
@@ -68,48 +117,73 @@ func (c *controller) updated(resource *apiv1.CloudResource) error {
 	}
 
 	// @step: if the result was an error we cannot proceed
-	if result == nil {
+	if result != nil {
 		return result
 	}
 
-	// @step: if the stack has any credentials we need to generate them
-	var credentials map[string]models.Credential
-
-	if template.Spec.Credentials {
-		credentials, err = c.updateCloudCredentials(ctx, resource, stack)
-		if err != nil {
-			return fmt.Errorf("unable to update / create credentials from stack: %s", err)
-		}
-	}
-
-	// @step: we need to map the outputs, secrets and credentials into the user namespace
-	if err := c.updateCloudSecrets(ctx, resource, stack, credentials); err != nil {
-		return fmt.Errorf("unable to update the kubernetes secrets")
-	}
-
-	return nil
+	// @step: generate the credentials and secrets, resuming from the last checkpoint of the stack
+	return c.reconcileSteps(ctx, resource, template, stack)
 }
 
 // updateCloudStatus is responsible for updating the cloud resource status
-func (c *controller) updateCloudStatus(ctx context.Context, stack *models.Stack, errMsg error, resource *apiv1.CloudResource) error {
+func (c *controller) updateCloudStatus(ctx context.Context, stack *models.Stack, errMsg error, resource *apiv1.CloudResource, diff *apiv1.StackDiff, operation *apiv1.StackOperation, conditions ...*apiv1.Condition) error {
 	status := &apiv1.CloudStatus{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      resource.Name,
 			Namespace: resource.Namespace,
 		},
+		Diff:      diff,
+		Operation: operation,
+	}
+	// @step: record the conditions i.e. the result of evaluating the template against the cloud policies
+	// and the deprecation of the template; a nil condition, such as the policies not being evaluated,
+	// retains the previous condition
+	for _, x := range conditions {
+		if x != nil {
+			status.SetCondition(*x)
+		}
+	}
+	// @step: record if the resource exceeds the namespace quotas
+	switch {
+	case isQuotaExceeded(errMsg):
+		status.SetCondition(apiv1.Condition{
+			Message: errMsg.Error(),
+			Reason:  apiv1.ConditionQuotaExceeded,
+			Status:  apiv1.ConditionTrue,
+			Type:    apiv1.ConditionQuotaExceeded,
+		})
+	case errMsg == nil:
+		status.SetCondition(apiv1.Condition{
+			Status: apiv1.ConditionFalse,
+			Type:   apiv1.ConditionQuotaExceeded,
+		})
+	}
+	// @step: clear the timed out condition once the stack has been reconciled without error
+	if errMsg == nil {
+		status.SetCondition(apiv1.Condition{
+			Status: apiv1.ConditionFalse,
+			Type:   apiv1.ConditionTimedOut,
+		})
 	}
+
 	if errMsg != nil {
-		status.Status = models.StatusFailed
-		status.Message = "Failed to update / create the stack"
-		status.Reason = errMsg.Error()
+		status.Status = models.PhaseFailed
+		status.Message = errMsg.Error()
+		status.Reason = getErrorReason(errMsg)
 
 		// @check if we have a stack to update
 		if stack == nil {
 			return utils.UpdateCloudStatus(c.options.ResourceClient, status)
 		}
-		status.Status = fmt.Sprintf("%s", stack.Status)
+	} else {
+		status.Status = stack.Status.Phase
+	}
+	status.Stack = &apiv1.StackState{
+		LastTransitionTime: metav1.NewTime(stack.Status.LastTransitionTime),
+		Phase:              stack.Status.Phase,
+		ProviderStatus:     stack.Status.ProviderStatus,
+		Reason:             stack.Status.Reason,
 	}
-	status.Status = fmt.Sprintf("%s", stack.Status)
 
 	// @step: grab the logs from the stack
 	logs, err := c.options.Cloud.Logs(ctx, stack.Name, &models.GetOptions{})
@@ -121,51 +195,64 @@ func (c *controller) updateCloudStatus(ctx context.Context, stack *models.Stack,
 	return utils.UpdateCloudStatus(c.options.ResourceClient, status)
 }
 
-// updateCloudResource is resposible for updating the resource
-func (c *controller) updateCloudResource(ctx context.Context, stackname string, resource *apiv1.CloudResource, template *apiv1.CloudTemplate) (*models.Stack, error) {
+// updateCloudResource is resposible for updating the resource; the stack is never waited upon, instead
+// the operation started, or found in progress, on the stack is returned along with the policy violation
+// condition when the cloud policies were evaluated
+func (c *controller) updateCloudResource(ctx context.Context, stackname string, resource *apiv1.CloudResource, resolved *models.ResolvedTemplate) (*models.Stack, *apiv1.StackOperation, *apiv1.Condition, error) {
+	template := resolved.Template
+
 	// @check if the stack already exists. It then checks the status of the stack
-	// waiting on those which haven't finished yet
 	stack, found, err := c.options.Cloud.Exists(ctx, stackname)
 	if err != nil {
-		return nil, fmt.Errorf("unable to check if stack exists already: %s", err)
+		return nil, nil, nil, models.WrapError(err, "unable to check if stack exists already")
 	}
-	checksum := getResourceChecksum(resource)
+	checksum := models.GetResourceChecksum(resource)
 	log.Debugf("calculated checksum for stack as: %s", checksum)
 
-	// @check if the resource has changed and if not we can return
+	// @check the phase of the stack, a deleted stack is recreated
 	if found {
-		status := stack.Status.Status
-		// if the stack is found, check the status of the stack and if not finished we need to
-	RETRY:
-		switch status {
-		case models.StatusDone:
-			return stack, nil
-		case models.StatusFailed:
-			return stack, fmt.Errorf("stack failed on previous creation: %s", stack.Status.Reason)
-		default:
-			if status, err = c.options.Cloud.Wait(ctx, stackname, &models.WaitOptions{}); err != nil {
-				return stack, fmt.Errorf("unable to wait on previous stack: %s", err)
-			}
+		switch {
+		case stack.Status.Phase == models.PhaseFailed:
+			return stack, nil, nil, models.NewError(models.ErrorClassStackFailed, stack.Status.ProviderStatus,
+				fmt.Errorf("stack has failed: %s (%s)", stack.Status.Reason, stack.Status.ProviderStatus))
+		case stack.Status.IsInProgress():
 			log.WithFields(log.Fields{
 				"namespace": resource.Namespace,
+				"phase":     stack.Status.Phase,
 				"resource":  resource.Name,
-			}).Info("rechecking the status of the stack")
-			goto RETRY
+			}).Info("stack has an operation in progress, waiting on it to complete")
+
+			operation := newStackOperation(apiv1.OperationWait, c.getStackTimeout(resource, template))
+			operation.Checksum = stack.CheckSum()
+
+			return stack, operation, nil, nil
+		case stack.Status.Phase == models.PhaseDeleted:
+			found = false
 		}
+	}
 
+	// @check if the resource has changed and if not we can return
+	if found {
 		// @check we have a checksum and check if its changed
 		sum := stack.CheckSum()
 		if sum == "" {
-			return stack, fmt.Errorf("stack does not have a checksum, refusing to continue")
+			return stack, nil, nil, fmt.Errorf("stack does not have a checksum, refusing to continue")
 		}
 
-		if sum == checksum {
+		// @check if a reconcile has been requested on the resource since the stack was updated
+		if sum == checksum && stack.Reconciled() == resource.GetAnnotations()[models.ReconcileAnnotation] {
+			// @check an update rolled back is reported rather than treated as healthy, a change to
+			// the resource or a reconcile request retries it
+			if stack.Status.Phase == models.PhaseRolledBack {
+				return stack, nil, nil, models.NewError(models.ErrorClassStackFailed, stack.Status.ProviderStatus,
+					fmt.Errorf("stack update was rolled back: %s", stack.Status.Reason))
+			}
 			log.WithFields(log.Fields{
 				"namespace": resource.Namespace,
 				"resource":  resource.Name,
 			}).Info("skipping updating the stack as nothing has changed")
 
-			return stack, nil
+			return stack, nil, nil, nil
 		}
 	}
 	log.WithFields(log.Fields{
@@ -175,77 +262,116 @@ func (c *controller) updateCloudResource(ctx context.Context, stackname string,
 
 	// @step: validate the cloud resource is ok
 	if errs := resource.IsValid(); len(errs) > 0 {
-		return stack, utils.GetErrors(errs)
+		return stack, nil, nil, models.NewError(models.ErrorClassInvalidTemplate, "", utils.GetErrors(errs))
 	}
 
 	// @check the template is valid and ok to us
 	if errs := template.IsValid(); len(errs) > 0 {
-		return stack, utils.GetErrors(errs)
+		return stack, nil, nil, models.NewError(models.ErrorClassInvalidTemplate, "", utils.GetErrors(errs))
 	}
 
-	// @step: we need build the parameters for the
-	model, err := c.makeResourceModel(template, resource)
+	// @step: enforce the namespace quotas before touching the stack
+	if err := c.checkCloudQuotas(resource, !found); err != nil {
+		return stack, nil, nil, err
+	}
+
+	options, err := c.makeCreateOptions(resource, resolved)
 	if err != nil {
-		return stack, err
+		return stack, nil, nil, err
+	}
+
+	// @step: render the template and evaluate it against the cloud policies
+	if options.Content, err = c.options.Cloud.Render(ctx, options); err != nil {
+		return stack, nil, nil, err
+	}
+	err = c.checkCloudPolicies(ctx, options)
+	evaluation := policyCondition(err)
+	if err != nil {
+		return stack, nil, evaluation, err
+	}
+
+	operation := newStackOperation(apiv1.OperationUpdate, c.getStackTimeout(resource, template))
+	operation.Checksum = checksum
+	if !found {
+		operation.Type = apiv1.OperationCreate
 	}
+	options.OperationID = operation.ID
 
 	log.WithFields(log.Fields{
-		"model":     model,
+		"model":     options.Context,
 		"namespace": resource.Namespace,
+		"operation": operation.Type,
 		"resource":  resource.Name,
 		"stackname": stackname,
 		"template":  template.Name,
 	}).Info("attempting to create the stack")
 
 	// @step: attempt to create the resource
-	err = c.options.Cloud.Create(ctx, stackname, &models.CreateOptions{
-		Context:  model,
-		Resource: resource,
-		Tags: map[string]string{
-			models.CheckSumTag:     checksum,
-			models.CreatedTag:      fmt.Sprintf("%d", time.Now().Unix()),
-			models.NamespaceTag:    resource.Namespace,
-			models.ProviderNameTag: c.config.Name,
-			models.ResourceNameTag: resource.Name,
-			models.RetentionTag:    fmt.Sprintf("%d", resource.Spec.Retention.Duration),
-			models.TemplateNameTag: resource.Spec.TemplateName,
-		},
-		Template: template,
-	})
-	if err != nil {
-		return stack, err
+	if err := c.options.Cloud.Create(ctx, stackname, options); err != nil {
+		if err != models.ErrNoUpdates {
+			return stack, nil, evaluation, err
+		}
+		// @check an update which changes nothing starts no operation, so there is nothing to record or poll
+		log.WithFields(log.Fields{
+			"namespace": resource.Namespace,
+			"resource":  resource.Name,
+		}).Info("stack already matches the update, no operation started")
+
+		return stack, nil, evaluation, nil
 	}
+	operationsCounter.WithLabelValues(operation.Type).Inc()
 
-	log.WithFields(log.Fields{
-		"namespace": resource.Namespace,
-		"resource":  resource.Name,
-		"stackname": stackname,
-	}).Info("successfully created the stack, waiting for the stack to complete")
+	// @step: retrieve the stack, the operation is polled by requeuing the resource
+	if stack, err = c.options.Cloud.Get(ctx, stackname, &models.GetOptions{}); err != nil {
+		return stack, nil, evaluation, err
+	}
+
+	return stack, operation, evaluation, nil
+}
+
+// makeCreateOptions builds the parameters, tags and render context of the stack for the resource
+func (c *controller) makeCreateOptions(resource *apiv1.CloudResource, resolved *models.ResolvedTemplate) (*models.CreateOptions, error) {
+	template := resolved.Template
 
-	// @step: attempt to wait for the stack to finish
-	status, err := c.options.Cloud.Wait(ctx, stackname, nil)
+	// @step: we need build the parameters for the
+	model, err := c.makeResourceModel(template, resource)
 	if err != nil {
-		return stack, err
+		return nil, err
 	}
 
-	log.WithFields(log.Fields{
-		"namespace": resource.Namespace,
-		"resource":  resource.Name,
-		"stackname": stackname,
-		"status":    status,
-	}).Info("successfully wait on the stack completion")
-
-	// @step: get the stacks
-	stack, err = c.options.Cloud.Get(ctx, stackname, &models.GetOptions{})
+	// @step: retrieve the namespace labels for the render context and policies
+	labels, err := c.getNamespaceLabels(resource.Namespace)
 	if err != nil {
-		return stack, err
+		return nil, err
 	}
 
-	if status != models.StatusDone {
-		return stack, errors.New("stack failed to complete successfully")
+	options := &models.CreateOptions{
+		Context:         model,
+		NamespaceLabels: labels,
+		Partials:        resolved.Partials,
+		Resource:        resource,
+		Tags:            models.MakeStackTags(resource, c.config.Name),
+		Template:        template,
+	}
+
+	return options, nil
+}
+
+// diffCloudResource is responsible for comparing the deployed stack with the template as it renders now
+func (c *controller) diffCloudResource(ctx context.Context, stackname string, resource *apiv1.CloudResource, resolved *models.ResolvedTemplate) (*apiv1.StackDiff, error) {
+	options, err := c.makeCreateOptions(resource.DeepCopy(), resolved)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := c.options.Cloud.Diff(ctx, stackname, options)
+	if err != nil {
+		return nil, err
+	}
+	if diff.HasChanges() {
+		stackDiffCounter.Inc()
 	}
 
-	return stack, nil
+	return diff, nil
 }
 
 // updateCloudCredentials is resposible for generating any credentials from the stack
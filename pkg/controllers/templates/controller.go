@@ -19,11 +19,18 @@ package templates
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	coreinform "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
@@ -34,10 +41,26 @@ import (
 	"github.com/gambol99/resources/pkg/utils"
 )
 
+const (
+	// contentSyncInterval is the interval the templates directory is checked for changes
+	contentSyncInterval = time.Second * 30
+	// summaryKeyPrefix prefixes the queue key of a template when only the summary of the resources
+	// using it has to be refreshed, rather than revalidating the template
+	summaryKeyPrefix = "summary:"
+)
+
 // the controller is used to monitor the changes in cloud templates
 type controller struct {
 	// informer is the lister
 	informer cache.SharedIndexInformer
+	// fragments is the template fragments informer
+	fragments cache.SharedIndexInformer
+	// configmaps is the informer for configmaps holding template content
+	configmaps cache.SharedIndexInformer
+	// resources is the informer for the cloud resources using the templates
+	resources cache.SharedIndexInformer
+	// statuses is the informer for the status of the cloud resources
+	statuses cache.SharedIndexInformer
 	// the worker queue
 	queue workqueue.RateLimitingInterface
 	// config are the controller config
@@ -60,20 +83,32 @@ func New(options *api.Options) (api.Controller, error) {
 
 // updated is called when a template has been updated or created
 func (c *controller) updated(template *apiv1.CloudTemplate) error {
+	// @check if the template is being deleted we hold it until no resources are using it
+	if template.DeletionTimestamp != nil {
+		return c.deleting(template)
+	}
+
 	log.WithFields(log.Fields{
-		"name": template.Name,
+		"engine": template.Spec.GetEngine(),
+		"name":   template.Name,
 	}).Info("checking the cloud template is valid")
 
-	// @check the template is valid and if not we need to update the status
-	switch errs := template.IsValid(); len(errs) > 0 {
-	case true:
-		template.Status = apiv1.TemplateSpecStatus{
-			Message: "The cloud template specification is invalid",
-			Reason:  utils.GetErrors(errs).Error(),
-			Status:  models.StatusTemplateInvalid,
-		}
-	default:
-		template.Status = apiv1.TemplateSpecStatus{Status: models.StatusTemplateOK}
+	status := c.validate(template)
+	status.Resources = c.summary(template.Name)
+
+	// @check if nothing has changed there is no need to update the template
+	if hasFinalizer(template) && equality.Semantic.DeepEqual(template.Status, status) {
+		log.WithFields(log.Fields{
+			"name": template.Name,
+		}).Debug("cloud template status has not changed, skipping the update")
+
+		return nil
+	}
+	template.Status = status
+
+	// @step: ensure the template cannot be removed while in use
+	if !hasFinalizer(template) {
+		template.Finalizers = append(template.Finalizers, models.TemplateFinalizer)
 	}
 
 	log.WithFields(log.Fields{
@@ -82,6 +117,69 @@ func (c *controller) updated(template *apiv1.CloudTemplate) error {
 		"reason": template.Status.Reason,
 	}).Debug("updating the cloud template status")
 
+	return c.update(template)
+}
+
+// summarised is called when the resources using the template have changed, only the summary of the
+// resources is refreshed and the template is updated if it differs
+func (c *controller) summarised(template *apiv1.CloudTemplate) error {
+	if template.DeletionTimestamp != nil {
+		return c.deleting(template)
+	}
+	summary := c.summary(template.Name)
+	if equality.Semantic.DeepEqual(template.Status.Resources, summary) {
+		return nil
+	}
+	template.Status.Resources = summary
+
+	return c.update(template)
+}
+
+// deleting is called when a template is being deleted; the finalizer is removed once no resources
+// reference the template, until then the resources are listed in the status
+func (c *controller) deleting(template *apiv1.CloudTemplate) error {
+	if !hasFinalizer(template) {
+		return nil
+	}
+
+	var blocking []string
+	for _, x := range c.dependents(template.Name) {
+		blocking = append(blocking, fmt.Sprintf("%s/%s", x.Namespace, x.Name))
+	}
+	sort.Strings(blocking)
+
+	if len(blocking) > 0 {
+		log.WithFields(log.Fields{
+			"name":      template.Name,
+			"resources": len(blocking),
+		}).Info("cloud template is still in use, blocking the deletion")
+
+		template.Status.BlockingResources = blocking
+		template.Status.Message = fmt.Sprintf("The cloud template cannot be deleted, it is used by %d resources", len(blocking))
+		template.Status.Reason = "TemplateInUse"
+		template.Status.Resources = c.summary(template.Name)
+		template.Status.Status = models.StatusTemplateDeleting
+
+		return c.update(template)
+	}
+
+	log.WithFields(log.Fields{
+		"name": template.Name,
+	}).Info("cloud template is no longer in use, removing the finalizer")
+
+	var finalizers []string
+	for _, x := range template.Finalizers {
+		if x != models.TemplateFinalizer {
+			finalizers = append(finalizers, x)
+		}
+	}
+	template.Finalizers = finalizers
+
+	return c.update(template)
+}
+
+// update is responsible for updating the template
+func (c *controller) update(template *apiv1.CloudTemplate) error {
 	// @step: attempt to update the template statue
 	return utils.Retry(5, time.Duration(time.Second*3), func() error {
 		_, err := c.options.ResourceClient.CloudV1().CloudTemplates().Update(template)
@@ -96,6 +194,393 @@ func (c *controller) updated(template *apiv1.CloudTemplate) error {
 	})
 }
 
+// dependents returns the cloud resources using the template from the informer cache
+func (c *controller) dependents(name string) []*apiv1.CloudResource {
+	var list []*apiv1.CloudResource
+	for _, obj := range c.resources.GetStore().List() {
+		if resource, ok := obj.(*apiv1.CloudResource); ok && resource.Spec.TemplateName == name {
+			list = append(list, resource)
+		}
+	}
+
+	return list
+}
+
+// summary counts the resources using the template by the status of their stack
+func (c *controller) summary(name string) apiv1.TemplateResourceSummary {
+	var summary apiv1.TemplateResourceSummary
+	for _, x := range c.dependents(name) {
+		summary.Total++
+
+		var state string
+		obj, exists, err := c.statuses.GetIndexer().GetByKey(fmt.Sprintf("%s/%s", x.Namespace, x.Name))
+		if err == nil && exists {
+			state = obj.(*apiv1.CloudStatus).Status
+		}
+
+		switch state {
+		case models.PhaseReady:
+			summary.Ready++
+		case models.PhaseFailed, models.PhaseRolledBack:
+			summary.Failed++
+		default:
+			summary.Progressing++
+		}
+	}
+
+	return summary
+}
+
+// enqueueResource queues the summary of the template used by the cloud resource
+func (c *controller) enqueueResource(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if resource, ok := obj.(*apiv1.CloudResource); ok && resource.Spec.TemplateName != "" {
+		c.queue.Add(summaryKeyPrefix + resource.Spec.TemplateName)
+	}
+}
+
+// updatedResource queues the summary of the templates when the template of the resource has changed,
+// the resource being added or removed is handled by the add and delete events
+func (c *controller) updatedResource(oldObj, newObj interface{}) {
+	before, after := oldObj.(*apiv1.CloudResource), newObj.(*apiv1.CloudResource)
+	if before.Spec.TemplateName != after.Spec.TemplateName {
+		c.enqueueResource(before)
+		c.enqueueResource(after)
+	}
+}
+
+// updatedStatus queues the summary of the template when the phase of the resource has changed
+func (c *controller) updatedStatus(oldObj, newObj interface{}) {
+	if oldObj.(*apiv1.CloudStatus).Status != newObj.(*apiv1.CloudStatus).Status {
+		c.enqueueStatus(newObj)
+	}
+}
+
+// enqueueStatus queues the summary of the template used by the cloud resource the status belongs to
+func (c *controller) enqueueStatus(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	if resource, exists, err := c.resources.GetIndexer().GetByKey(key); err == nil && exists {
+		c.enqueueResource(resource)
+	}
+}
+
+// hasFinalizer checks if the template has the template finalizer
+func hasFinalizer(template *apiv1.CloudTemplate) bool {
+	for _, x := range template.Finalizers {
+		if x == models.TemplateFinalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validate checks the template specification, content and dependencies, returning the status
+func (c *controller) validate(template *apiv1.CloudTemplate) apiv1.TemplateSpecStatus {
+	// @check the template is valid and if not we need to update the status
+	if errs := template.IsValid(); len(errs) > 0 {
+		return apiv1.TemplateSpecStatus{
+			Message: "The cloud template specification is invalid",
+			Reason:  utils.GetErrors(errs).Error(),
+			Status:  models.StatusTemplateInvalid,
+		}
+	}
+
+	// @step: retrieve the content from the configmap or templates directory if not inline
+	loaded, err := c.options.Content.Load(template)
+	if err != nil {
+		return apiv1.TemplateSpecStatus{
+			Message: "The cloud template content could not be loaded",
+			Reason:  err.Error(),
+			Status:  models.StatusTemplateInvalid,
+		}
+	}
+	status := apiv1.TemplateSpecStatus{
+		Checksum: utils.ContentChecksum(loaded.Spec.Content),
+		Status:   models.StatusTemplateOK,
+	}
+
+	// @check the template content can be parsed by the rendering engine of the template
+	if err := c.options.Cloud.Validate(context.Background(), loaded); err != nil {
+		status.Message = fmt.Sprintf("The cloud template content is invalid for the engine: %s", template.Spec.GetEngine())
+		status.Reason = err.Error()
+		status.Status = models.StatusTemplateInvalid
+
+		return status
+	}
+
+	// @check the base templates and fragments can be resolved and parsed
+	if err := c.validateDependencies(loaded); err != nil {
+		status.Message = "The cloud template dependencies are invalid"
+		status.Reason = err.Error()
+		status.Status = models.StatusTemplateInvalid
+
+		return status
+	}
+
+	// @check the successor of a deprecated template can be migrated to
+	if err := c.validateSuccessor(loaded); err != nil {
+		status.Message = "The cloud template successor is invalid"
+		status.Reason = err.Error()
+		status.Status = models.StatusTemplateInvalid
+
+		return status
+	}
+
+	// @step: resolve the content of the bases and fragments for the analysis and tests
+	resolved, err := models.ResolveTemplate(loaded, c.getLoadedTemplate, c.getFragment)
+	if err != nil {
+		status.Message = "The cloud template dependencies are invalid"
+		status.Reason = err.Error()
+		status.Status = models.StatusTemplateInvalid
+
+		return status
+	}
+
+	// @check the parameters and outputs declared by the template against the content
+	findings, err := c.analyze(resolved)
+	if err != nil {
+		status.Message = "The cloud template could not be analysed"
+		status.Reason = err.Error()
+		status.Status = models.StatusTemplateInvalid
+
+		return status
+	}
+	status.Findings = findings
+	if models.HasFindingErrors(findings) {
+		status.Message = "The cloud template parameters or outputs are invalid"
+		status.Reason = "TemplateFindings"
+		status.Status = models.StatusTemplateInvalid
+
+		return status
+	}
+
+	// @check the test cases of the template pass
+	if len(template.Spec.Tests) > 0 {
+		outcomes, err := c.options.Cloud.Test(context.Background(), resolved.Template, resolved.Partials)
+		if err != nil {
+			status.Message = "The cloud template tests could not be run"
+			status.Reason = err.Error()
+			status.Status = models.StatusTemplateInvalid
+
+			return status
+		}
+		status.Tests = models.GetTemplateTestResults(outcomes)
+
+		var failed []string
+		for _, x := range outcomes {
+			if !x.Passed() {
+				failed = append(failed, x.Name)
+			}
+		}
+		if len(failed) > 0 {
+			status.Message = fmt.Sprintf("The cloud template tests have failed: %s", strings.Join(failed, ","))
+			status.Reason = "TemplateTestsFailed"
+			status.Status = models.StatusTemplateInvalid
+
+			return status
+		}
+	}
+
+	if template.Spec.Deprecated {
+		status.Message = template.GetDeprecationWarning()
+	}
+
+	return status
+}
+
+// analyze compares the parameters and secrets of the resolved template with the parameters
+// referenced by the content and the outputs it renders
+func (c *controller) analyze(resolved *models.ResolvedTemplate) ([]apiv1.TemplateFinding, error) {
+	analysis, err := c.options.Cloud.Analyze(context.Background(), resolved.Template, resolved.Partials)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.GetTemplateFindings(resolved.Template, analysis), nil
+}
+
+// validateSuccessor checks the successor template exists and declares the mapped parameters
+func (c *controller) validateSuccessor(template *apiv1.CloudTemplate) error {
+	if template.Spec.Successor == nil {
+		return nil
+	}
+	successor, err := c.getTemplate(template.Spec.Successor.Name)
+	if err != nil {
+		return err
+	}
+	resolved, err := c.resolve(successor)
+	if err != nil {
+		return fmt.Errorf("unable to resolve successor template: %s, error: %s", successor.Name, err)
+	}
+
+	declared := make(map[string]bool, 0)
+	for _, x := range resolved.Template.Spec.Parameters {
+		declared[x.Name] = true
+	}
+	for _, x := range template.Spec.Successor.Parameters {
+		if !declared[x.To] {
+			return fmt.Errorf("parameter: %s is not declared by the successor template: %s", x.To, successor.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateDependencies checks the inheritance chain of the template resolves without a cycle and
+// the fragments it includes are valid
+func (c *controller) validateDependencies(template *apiv1.CloudTemplate) error {
+	resolved, err := models.ResolveTemplate(template, c.getLoadedTemplate, c.getFragment)
+	if err != nil {
+		return err
+	}
+	for _, x := range resolved.Dependencies {
+		if !strings.HasPrefix(x, models.DependencyFragment) {
+			continue
+		}
+		fragment, err := c.getFragment(strings.TrimPrefix(x, models.DependencyFragment))
+		if err != nil {
+			return err
+		}
+		if errs := fragment.IsValid(); len(errs) > 0 {
+			return fmt.Errorf("fragment: %s is invalid, %s", fragment.Name, utils.GetErrors(errs))
+		}
+		if err := c.options.Cloud.Validate(context.Background(), &apiv1.CloudTemplate{
+			Spec: apiv1.TemplateSpec{Content: fragment.Spec.Content},
+		}); err != nil {
+			return fmt.Errorf("fragment: %s is invalid, %s", fragment.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getLoadedTemplate retrieves the template from the informer cache with the content loaded
+func (c *controller) getLoadedTemplate(name string) (*apiv1.CloudTemplate, error) {
+	template, err := c.getTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.options.Content.Load(template)
+}
+
+// resolve resolves the dependencies of the template from the informer caches without loading the content
+func (c *controller) resolve(template *apiv1.CloudTemplate) (*models.ResolvedTemplate, error) {
+	return models.ResolveTemplate(template, c.getTemplate, c.getFragment)
+}
+
+// getTemplate retrieves the template from the informer cache
+func (c *controller) getTemplate(name string) (*apiv1.CloudTemplate, error) {
+	obj, exists, err := c.informer.GetIndexer().GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("cloud template: %s does not exist", name)
+	}
+
+	return obj.(*apiv1.CloudTemplate), nil
+}
+
+// getFragment retrieves the template fragment from the informer cache
+func (c *controller) getFragment(name string) (*apiv1.CloudTemplateFragment, error) {
+	obj, exists, err := c.fragments.GetIndexer().GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("template fragment: %s does not exist", name)
+	}
+
+	return obj.(*apiv1.CloudTemplateFragment), nil
+}
+
+// enqueueDependents queues the templates which depend on the template or fragment for revalidation;
+// templates which fail to resolve are also queued as the change may have fixed or caused it, as are
+// deprecated templates whose successor has changed
+func (c *controller) enqueueDependents(dependency string) {
+	for _, obj := range c.informer.GetStore().List() {
+		template, ok := obj.(*apiv1.CloudTemplate)
+		if !ok {
+			continue
+		}
+		successor := template.Spec.Successor != nil && models.DependencyTemplate+template.Spec.Successor.Name == dependency
+		resolved, err := c.resolve(template)
+		if err == nil && !resolved.DependsOn(dependency) && !successor {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"dependency": dependency,
+			"name":       template.Name,
+		}).Debug("revalidating the dependent cloud template")
+
+		c.queue.Add(template.Name)
+	}
+}
+
+// enqueueConfigMap queues the templates sourcing their content from the configmap and their dependents
+func (c *controller) enqueueConfigMap(namespace, name string) {
+	for _, obj := range c.informer.GetStore().List() {
+		template, ok := obj.(*apiv1.CloudTemplate)
+		if !ok {
+			continue
+		}
+		source := template.Spec.ContentFrom
+		if source == nil || source.ConfigMapKeyRef == nil {
+			continue
+		}
+		if source.ConfigMapKeyRef.Namespace != namespace || source.ConfigMapKeyRef.Name != name {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"configmap": fmt.Sprintf("%s/%s", namespace, name),
+			"name":      template.Name,
+		}).Debug("template content source has changed, revalidating the template")
+
+		c.queue.Add(template.Name)
+		c.enqueueDependents(models.DependencyTemplate + template.Name)
+	}
+}
+
+// syncFiles queues the templates whose content file in the templates directory no longer matches
+// the checksum of the last validation
+func (c *controller) syncFiles() {
+	for _, obj := range c.informer.GetStore().List() {
+		template, ok := obj.(*apiv1.CloudTemplate)
+		if !ok {
+			continue
+		}
+		source := template.Spec.ContentFrom
+		if source == nil || source.File == "" {
+			continue
+		}
+
+		var checksum string
+		if loaded, err := c.options.Content.Load(template); err == nil {
+			checksum = utils.ContentChecksum(loaded.Spec.Content)
+		}
+		if checksum == template.Status.Checksum {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"file": source.File,
+			"name": template.Name,
+		}).Debug("template content file has changed, revalidating the template")
+
+		c.queue.Add(template.Name)
+		c.enqueueDependents(models.DependencyTemplate + template.Name)
+	}
+}
+
 // Run is responsible for starting the controller up
 func (c *controller) Run(ctx context.Context) error {
 	// @step: we create a namespace informer
@@ -108,21 +593,99 @@ func (c *controller) Run(ctx context.Context) error {
 			}
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
-			key, err := cache.MetaNamespaceKeyFunc(newObj)
-			if err == nil {
-				c.queue.Add(key)
+			// @check the template is only revalidated on a change to the specification, the deletion or a
+			// resync; the updates to the status made by the controller are ignored
+			before, after := oldObj.(*apiv1.CloudTemplate), newObj.(*apiv1.CloudTemplate)
+			changed := !reflect.DeepEqual(before.Spec, after.Spec)
+			if changed || before.ResourceVersion == after.ResourceVersion || before.DeletionTimestamp != after.DeletionTimestamp {
+				if key, err := cache.MetaNamespaceKeyFunc(newObj); err == nil {
+					c.queue.Add(key)
+				}
+			}
+			// @check if the specification has changed we need to revalidate the templates extending it
+			if changed {
+				c.enqueueDependents(models.DependencyTemplate + after.Name)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				c.enqueueDependents(models.DependencyTemplate + key)
 			}
 		},
-		DeleteFunc: func(obj interface{}) {},
 	})
 	defer c.queue.ShutDown()
 
-	// @step: start the shared index informer
+	// @step: we create a template fragments informer, changes revalidate the dependent templates
+	c.fragments = inform.NewCloudTemplateFragmentInformer(c.options.ResourceClient, c.options.ResyncDuration, cache.Indexers{})
+	c.fragments.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				c.enqueueDependents(models.DependencyFragment + key)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(newObj); err == nil {
+				c.enqueueDependents(models.DependencyFragment + key)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				c.enqueueDependents(models.DependencyFragment + key)
+			}
+		},
+	})
+
+	// @step: we create an informer for the configmaps holding template content
+	c.configmaps = coreinform.NewFilteredConfigMapInformer(c.options.Client, metav1.NamespaceAll, c.options.ResyncDuration, cache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = fmt.Sprintf("%s=true", models.TemplateContentLabel)
+		},
+	)
+	c.configmaps.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if cm, ok := obj.(*core.ConfigMap); ok {
+				c.enqueueConfigMap(cm.Namespace, cm.Name)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if cm, ok := newObj.(*core.ConfigMap); ok {
+				c.enqueueConfigMap(cm.Namespace, cm.Name)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				if namespace, name, err := cache.SplitMetaNamespaceKey(key); err == nil {
+					c.enqueueConfigMap(namespace, name)
+				}
+			}
+		},
+	})
+
+	// @step: we create informers for the resources and their status, changes update the usage of the templates
+	c.resources = inform.NewCloudResourceInformer(c.options.ResourceClient, metav1.NamespaceAll, c.options.ResyncDuration, cache.Indexers{})
+	c.resources.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueResource,
+		UpdateFunc: c.updatedResource,
+		DeleteFunc: c.enqueueResource,
+	})
+	c.statuses = inform.NewCloudStatusInformer(c.options.ResourceClient, metav1.NamespaceAll, c.options.ResyncDuration, cache.Indexers{})
+	c.statuses.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueStatus,
+		UpdateFunc: c.updatedStatus,
+		DeleteFunc: c.enqueueStatus,
+	})
+
+	// @step: start the shared index informers
 	stopCh := make(chan struct{}, 0)
 	go c.informer.Run(stopCh)
+	go c.fragments.Run(stopCh)
+	go c.configmaps.Run(stopCh)
+	go c.resources.Run(stopCh)
+	go c.statuses.Run(stopCh)
 
 	log.WithFields(log.Fields{"controller": c.Name()}).Info("waiting for controller caches to synchronize")
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced, c.fragments.HasSynced, c.configmaps.HasSynced,
+		c.resources.HasSynced, c.statuses.HasSynced) {
 		runtime.HandleError(fmt.Errorf("%s controller timed out waiting for caches to sync", c.Name()))
 		return fmt.Errorf("%s controller timed out waiting for cache sync", c.Name())
 	}
@@ -131,6 +694,21 @@ func (c *controller) Run(ctx context.Context) error {
 	for i := 0; i < c.options.Threadness; i++ {
 		go c.processItems()
 	}
+	// @step: the templates directory is polled for changes to the content files
+	if c.config.TemplatesDir != "" {
+		go func() {
+			ticker := time.NewTicker(contentSyncInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					c.syncFiles()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
 	// @step: wait for a signal to stop
 	select {
 	case <-ctx.Done():
@@ -184,6 +762,10 @@ func (c *controller) processEvent(key string) error {
 		return nil
 	}
 
+	// @check if only the summary of the resources using the template needs refreshing
+	summaryOnly := strings.HasPrefix(key, summaryKeyPrefix)
+	key = strings.TrimPrefix(key, summaryKeyPrefix)
+
 	obj, exists, err := c.informer.GetIndexer().GetByKey(key)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -213,8 +795,11 @@ func (c *controller) processEvent(key string) error {
 	if !ok {
 		return fmt.Errorf("object should have been a cloudresource")
 	}
+	if summaryOnly {
+		return c.summarised(resource.DeepCopy())
+	}
 
-	return c.updated(resource)
+	return c.updated(resource.DeepCopy())
 }
 
 // Name returns the name of the controller
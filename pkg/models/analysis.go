@@ -0,0 +1,117 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+// TemplateAnalysis is what the provider found in the content of a template
+type TemplateAnalysis struct {
+	// References are the parameters referenced by the content, nil when the engine does not support
+	// finding the references
+	References []string
+	// Outputs are the keys of the outputs section of the template rendered with placeholder values,
+	// nil when the outputs are unknown
+	Outputs []string
+	// RenderError is the reason the template could not be rendered with placeholder values
+	RenderError error
+}
+
+// GetTemplateFindings compares the parameters and secrets declared by the template with those the
+// content references and the outputs it renders
+func GetTemplateFindings(template *apiv1.CloudTemplate, analysis *TemplateAnalysis) []apiv1.TemplateFinding {
+	var findings []apiv1.TemplateFinding
+
+	spec := field.NewPath("spec")
+
+	// @step: compare the declared parameters with those referenced by the content
+	if analysis.References != nil {
+		referenced := make(map[string]bool, len(analysis.References))
+		for _, x := range analysis.References {
+			referenced[x] = true
+		}
+		declared := make(map[string]bool, len(template.Spec.Parameters))
+		for i, x := range template.Spec.Parameters {
+			declared[x.Name] = true
+			if !referenced[x.Name] {
+				findings = append(findings, apiv1.TemplateFinding{
+					Field:    spec.Child("parameters").Index(i).String(),
+					Message:  fmt.Sprintf("parameter: %s is declared but never referenced by the content", x.Name),
+					Severity: apiv1.FindingSeverityWarning,
+					Type:     apiv1.FindingUnusedParameter,
+				})
+			}
+		}
+		for _, x := range analysis.References {
+			if !declared[x] {
+				findings = append(findings, apiv1.TemplateFinding{
+					Field:    spec.Child("content").String(),
+					Message:  fmt.Sprintf("parameter: %s is referenced by the content but not declared", x),
+					Severity: apiv1.FindingSeverityError,
+					Type:     apiv1.FindingUndeclaredParameter,
+				})
+			}
+		}
+	}
+
+	// @step: check the secret output mappings against the outputs of the rendered template
+	if analysis.RenderError != nil {
+		findings = append(findings, apiv1.TemplateFinding{
+			Field:    spec.Child("content").String(),
+			Message:  fmt.Sprintf("unable to render the template to check the outputs: %s", analysis.RenderError),
+			Severity: apiv1.FindingSeverityWarning,
+			Type:     apiv1.FindingOutputsUnchecked,
+		})
+	}
+	if analysis.Outputs != nil {
+		outputs := make(map[string]bool, len(analysis.Outputs))
+		for _, x := range analysis.Outputs {
+			outputs[x] = true
+		}
+		for i, x := range template.Spec.Secrets {
+			for j, v := range x.Values {
+				if v.Type != apiv1.SecretTypeOutput || outputs[v.Value] {
+					continue
+				}
+				findings = append(findings, apiv1.TemplateFinding{
+					Field:    spec.Child("secrets").Index(i).Child("values").Index(j).String(),
+					Message:  fmt.Sprintf("secret: %s maps the output: %s which the template does not define", x.Name, v.Value),
+					Severity: apiv1.FindingSeverityError,
+					Type:     apiv1.FindingUnknownOutput,
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// HasFindingErrors checks if any of the findings is an error
+func HasFindingErrors(findings []apiv1.TemplateFinding) bool {
+	for _, x := range findings {
+		if x.Severity == apiv1.FindingSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+func newTestAnalysisTemplate() *apiv1.CloudTemplate {
+	return &apiv1.CloudTemplate{
+		Spec: apiv1.TemplateSpec{
+			Parameters: []apiv1.Parameter{{Name: "bucket"}, {Name: "unused"}},
+			Secrets: []apiv1.Secret{
+				{
+					Name: "bucket",
+					Values: []apiv1.SecretValue{
+						{Type: apiv1.SecretTypeOutput, Key: "name", Value: "Bucket"},
+						{Type: apiv1.SecretTypeOutput, Key: "arn", Value: "Arn"},
+						{Type: apiv1.SecretTypeCredential, Key: "key", Value: "AccessKey"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetTemplateFindings(t *testing.T) {
+	findings := GetTemplateFindings(newTestAnalysisTemplate(), &TemplateAnalysis{
+		References: []string{"bucket", "missing"},
+		Outputs:    []string{"Bucket"},
+	})
+
+	assert.Equal(t, []apiv1.TemplateFinding{
+		{
+			Field:    "spec.parameters[1]",
+			Message:  "parameter: unused is declared but never referenced by the content",
+			Severity: apiv1.FindingSeverityWarning,
+			Type:     apiv1.FindingUnusedParameter,
+		},
+		{
+			Field:    "spec.content",
+			Message:  "parameter: missing is referenced by the content but not declared",
+			Severity: apiv1.FindingSeverityError,
+			Type:     apiv1.FindingUndeclaredParameter,
+		},
+		{
+			Field:    "spec.secrets[0].values[1]",
+			Message:  "secret: bucket maps the output: Arn which the template does not define",
+			Severity: apiv1.FindingSeverityError,
+			Type:     apiv1.FindingUnknownOutput,
+		},
+	}, findings)
+	assert.True(t, HasFindingErrors(findings))
+}
+
+func TestGetTemplateFindingsUnknown(t *testing.T) {
+	findings := GetTemplateFindings(newTestAnalysisTemplate(), &TemplateAnalysis{RenderError: errors.New("failed")})
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, apiv1.FindingOutputsUnchecked, findings[0].Type)
+	assert.False(t, HasFindingErrors(findings))
+}
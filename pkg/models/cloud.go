@@ -25,8 +25,14 @@ import (
 )
 
 var (
+	// ErrNoUpdates indicates the stack already matches the update, so no operation was started
+	ErrNoUpdates = errors.New("no updates are to be performed")
+	// ErrNotCancellable indicates the operation in progress on the stack cannot be cancelled
+	ErrNotCancellable = errors.New("operation cannot be cancelled")
 	// ErrOperationAborted indicates the operation was aborted
 	ErrOperationAborted = errors.New("operation aborted")
+	// ErrOperationTimeout indicates the operation did not complete within the timeout
+	ErrOperationTimeout = errors.New("operation timed out")
 	// ErrStackNotFound indicates the resource stack was not found
 	ErrStackNotFound = errors.New("stack not found")
 	// ErrUnauthorized indicates you trying to delete a stacks was you do not own
@@ -38,6 +44,9 @@ type ProviderConfig struct {
 	// ClusterName is the name of the cluster
 	// +required
 	ClusterName string
+	// LookupTTL is the duration template lookups are cached across renders, zero disables
+	// +optional
+	LookupTTL time.Duration
 	// Regon is region the cluster resides
 	// +optional
 	Region string
@@ -48,9 +57,21 @@ type ProviderConfig struct {
 
 // CreateOptions is a set of providers for the provider
 type CreateOptions struct {
+	// Content is the rendered template, when empty the template is rendered by the provider
+	// +optional
+	Content string
 	// Context is a set of contextual values
 	// +required
 	Context map[string]string
+	// NamespaceLabels are the labels on the namespace of the resource
+	// +optional
+	NamespaceLabels map[string]string
+	// OperationID is a unique id for the operation, used to make the request idempotent
+	// +optional
+	OperationID string
+	// Partials are the fragments and base templates parsed before the template content
+	// +optional
+	Partials []string
 	// Resource is the resource we are creating
 	// +required
 	Resource *apiv1.CloudResource
@@ -80,6 +101,9 @@ type WaitOptions struct {
 	// CheckInterval is the duration to wait before checking
 	// +optional
 	CheckInterval time.Duration
+	// Timeout is the duration to wait on the stack, zero waits until the context is done
+	// +optional
+	Timeout time.Duration
 }
 
 // ListOptions are used for list options
@@ -99,12 +123,18 @@ type CredentialsOptions struct {
 
 // CloudProvider defined the cloud provider contract
 type CloudProvider interface {
+	// Cancel is responsible for cancelling the operation in progress on the stack
+	Cancel(context.Context, string) error
 	// Credentials generates the credentials from a stack
 	Credentials(context.Context, string) ([]Credential, error)
-	// Create is responsible for creating or updating a stack
+	// Create is responsible for creating or updating a stack, ErrNoUpdates when the update changes nothing
 	Create(context.Context, string, *CreateOptions) error
 	// Delete is responsible for removing the stack
 	Delete(context.Context, string, *DeleteOptions) error
+	// Diff is responsible for comparing the deployed stack with the template rendered from the options
+	Diff(context.Context, string, *CreateOptions) (*apiv1.StackDiff, error)
+	// Events retrieves the events of the stack, most recent first
+	Events(context.Context, string, *GetOptions) ([]StackEvent, error)
 	// Exists is responsible for checking is stack already exists
 	Exists(context.Context, string) (*Stack, bool, error)
 	// Get is responisble for retrieving a stack
@@ -113,31 +143,52 @@ type CloudProvider interface {
 	List(context.Context, *ListOptions) ([]*Stack, error)
 	// Logs gets the logs on the stack
 	Logs(context.Context, string, *GetOptions) (string, error)
-	// Status is responsible for getting the status
+	// OperationStatus is responsible for checking the state of an operation on the stack without waiting
+	OperationStatus(context.Context, string, *apiv1.StackOperation) (*StackStatus, error)
+	// Render is responsible for generating the stack template from the options
+	Render(context.Context, *CreateOptions) (string, error)
+	// Status is responsible for getting the phase of the stack
 	Status(context.Context, string, *GetOptions) (string, error)
+	// Test is responsible for running the test cases of the template without calling the cloud
+	Test(context.Context, *apiv1.CloudTemplate, []string) ([]TemplateTestOutcome, error)
+	// Validate is responsible for checking the template content can be parsed
+	Validate(context.Context, *apiv1.CloudTemplate) error
+	// Analyze is responsible for finding the parameters and outputs of the template and partials
+	Analyze(context.Context, *apiv1.CloudTemplate, []string) (*TemplateAnalysis, error)
 	// UpdateTags is responsible for updating just the tags of a stack
 	UpdateTags(context.Context, string, map[string]string) error
-	// Wait is responsible for waiting for a stack to complete or fail
+	// Wait is responsible for waiting for a stack to complete or fail, returning the phase
 	Wait(context.Context, string, *WaitOptions) (string, error)
 }
 
 const (
-	// StatusDone indicates the operation is complete
-	StatusDone = "OK"
-	// StatusFailed indicates the stack has failed
-	StatusFailed = "Failed"
-	// StatusDeleting indicates the stack is being deleted
-	StatusDeleting = "Deleting"
-	// StatusInProgress indicates the stack is in progress
-	StatusInProgress = "InProgress"
-	// StatusInRollback indicates the stack is in rollback
-	StatusInRollback = "Rollback"
-	// StatusUnknown indicates an unknown status
-	StatusUnknown = ""
+	// PhaseCreating indicates the stack is being created
+	PhaseCreating = "Creating"
+	// PhaseDeleted indicates the stack has been deleted
+	PhaseDeleted = "Deleted"
+	// PhaseDeleting indicates the stack is being deleted
+	PhaseDeleting = "Deleting"
+	// PhaseFailed indicates the stack has failed and cannot be updated
+	PhaseFailed = "Failed"
+	// PhaseReady indicates the stack has been created or updated
+	PhaseReady = "Ready"
+	// PhaseRolledBack indicates the last update of the stack failed and was rolled back
+	PhaseRolledBack = "RolledBack"
+	// PhaseRollingBack indicates a failed update of the stack is being rolled back
+	PhaseRollingBack = "RollingBack"
+	// PhaseUnknown indicates the status of the stack is not known
+	PhaseUnknown = ""
+	// PhaseUpdating indicates the stack is being updated
+	PhaseUpdating = "Updating"
+)
+
+const (
 	// StatusTemplateOK indicates the template has passed validation
 	StatusTemplateOK = "OK"
 	// StatusTemplateInvalid indicates the template is invalid
 	StatusTemplateInvalid = "Invalid"
+	// StatusTemplateDeleting indicates the template is being deleted but still in use
+	StatusTemplateDeleting = "Deleting"
 )
 
 const (
@@ -153,6 +204,8 @@ const (
 	ProviderNameTag = ProviderTag + "/provider"
 	// ProviderTag is the name of the provider
 	ProviderTag = "resources.appvia.io"
+	// ReconcileTag is the reconcile request of the resource the stack was last updated for
+	ReconcileTag = ProviderTag + "/reconcile"
 	// ResourceNameTag is the resource tag
 	ResourceNameTag = ProviderTag + "/resource"
 	// RetentionTag is the tag used for the retention
@@ -161,6 +214,27 @@ const (
 	TemplateNameTag = ProviderTag + "/template"
 )
 
+const (
+	// PolicyLabel is the label on configmaps holding rego policies
+	PolicyLabel = ProviderTag + "/policy"
+	// TemplateContentLabel is the label on configmaps holding template content
+	TemplateContentLabel = ProviderTag + "/template-content"
+	// ManagedByLabel is the label on templates created by the controller
+	ManagedByLabel = ProviderTag + "/managed-by"
+	// ManagedByBundle indicates the template is managed by the bundle sync
+	ManagedByBundle = "bundle"
+	// TemplateFinalizer is the finalizer preventing the deletion of templates still in use
+	TemplateFinalizer = ProviderTag + "/template-protection"
+	// MigrateAnnotation opts a resource into migration from a deprecated template to its successor
+	MigrateAnnotation = ProviderTag + "/migrate"
+	// MigratedFromAnnotation records the deprecated template a resource was migrated from
+	MigratedFromAnnotation = ProviderTag + "/migrated-from"
+	// BundleChecksumAnnotation is the checksum of the bundle entry a template was synced from
+	BundleChecksumAnnotation = ProviderTag + "/bundle-checksum"
+	// ReconcileAnnotation forces the stack of a resource to be updated when its value changes
+	ReconcileAnnotation = ProviderTag + "/reconcile"
+)
+
 // Stack is an instance of a resource in the cloud
 type Stack struct {
 	// Created is the time the resource was created
@@ -177,6 +251,8 @@ type Stack struct {
 
 // StackSpec is the specification for the for a stack
 type StackSpec struct {
+	// Content is the template body deployed to the stack
+	Content string `json:"content" yaml:"content"`
 	// DeleteOn is the deletion time if it has one
 	DeleteOn time.Time `json:"deleteOn" yaml:"deleteOn"`
 	// Name is the name of the actual cloud resource
@@ -193,12 +269,35 @@ type StackSpec struct {
 
 // StackStatus the status of a stack
 type StackStatus struct {
-	// Status the status of the stack
-	Status string `json:"status" yaml:"status"`
-	// Reasion is a reasion for the status
+	// LastTransitionTime is the time the stack last changed status
+	LastTransitionTime time.Time `json:"lastTransitionTime" yaml:"lastTransitionTime"`
+	// Phase is the phase of the stack i.e. Creating, Updating, Ready, RollingBack, RolledBack, Failed,
+	// Deleting or Deleted
+	Phase string `json:"phase" yaml:"phase"`
+	// ProviderStatus is the raw status of the stack in the cloud provider
+	ProviderStatus string `json:"providerStatus" yaml:"providerStatus"`
+	// Reason is a reason for the status
 	Reason string `json:"reason" yaml:"reason"`
 }
 
+// StackEvent is an event from the stack
+type StackEvent struct {
+	// ID is the unique id of the event
+	ID string `json:"id" yaml:"id"`
+	// LogicalID is the name of the resource in the template
+	LogicalID string `json:"logicalID" yaml:"logicalID"`
+	// PhysicalID is the id of the resource in the cloud
+	PhysicalID string `json:"physicalID" yaml:"physicalID"`
+	// Reason is the reason for the status
+	Reason string `json:"reason" yaml:"reason"`
+	// Status is the status of the resource
+	Status string `json:"status" yaml:"status"`
+	// Time is the time of the event
+	Time time.Time `json:"time" yaml:"time"`
+	// Type is the type of the resource
+	Type string `json:"type" yaml:"type"`
+}
+
 // Credential is response from a credential creation
 type Credential struct {
 	// ID is the name of this credential
@@ -0,0 +1,169 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+// volatileTags are the stack tags which change on every update and are ignored when comparing
+var volatileTags = []string{CreatedTag, DeletionTimeTag}
+
+// parseTemplate decodes the yaml or json template, so templates differing only in format or key
+// order decode to the same document
+func parseTemplate(content string) (interface{}, error) {
+	encoded, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// DiffDocuments returns the changes between the documents, one line per changed path prefixed with
+// + for added, - for removed and ~ for changed values; the keys of maps are compared in sorted order
+func DiffDocuments(deployed, rendered interface{}) []string {
+	var changes []string
+	diffDocuments("", deployed, rendered, &changes)
+
+	return changes
+}
+
+// diffDocuments walks the documents recording the changes
+func diffDocuments(path string, a, b interface{}, changes *[]string) {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool, 0)
+		for k := range x {
+			keys[k] = true
+		}
+		for k := range y {
+			keys[k] = true
+		}
+		var list []string
+		for k := range keys {
+			list = append(list, k)
+		}
+		sort.Strings(list)
+		for _, k := range list {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			av, inA := x[k]
+			bv, inB := y[k]
+			switch {
+			case !inA:
+				*changes = append(*changes, fmt.Sprintf("+ %s: %s", child, encodeValue(bv)))
+			case !inB:
+				*changes = append(*changes, fmt.Sprintf("- %s: %s", child, encodeValue(av)))
+			default:
+				diffDocuments(child, av, bv, changes)
+			}
+		}
+		return
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(x) || i < len(y); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(x):
+				*changes = append(*changes, fmt.Sprintf("+ %s: %s", child, encodeValue(y[i])))
+			case i >= len(y):
+				*changes = append(*changes, fmt.Sprintf("- %s: %s", child, encodeValue(x[i])))
+			default:
+				diffDocuments(child, x[i], y[i], changes)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, fmt.Sprintf("~ %s: %s -> %s", path, encodeValue(a), encodeValue(b)))
+	}
+}
+
+// encodeValue returns the value as compact json
+func encodeValue(v interface{}) string {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(encoded)
+}
+
+// DiffStack compares the deployed stack with the rendered template and tags
+func DiffStack(stack *Stack, rendered string, tags map[string]string) (*apiv1.StackDiff, error) {
+	deployed, err := parseTemplate(stack.Spec.Content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the deployed template: %s", err)
+	}
+	current, err := parseTemplate(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the rendered template: %s", err)
+	}
+
+	diff := &apiv1.StackDiff{
+		LastCheckedTime: metav1.NewTime(time.Now()),
+		Template:        strings.Join(DiffDocuments(deployed, current), "\n"),
+	}
+
+	// @step: compare the tags, ignoring those which change on every update
+	keys := make(map[string]bool, 0)
+	for k := range stack.Spec.Tags {
+		keys[k] = true
+	}
+	for k := range tags {
+		keys[k] = true
+	}
+	for _, x := range volatileTags {
+		delete(keys, x)
+	}
+	var list []string
+	for k := range keys {
+		if stack.Spec.Tags[k] != tags[k] {
+			list = append(list, k)
+		}
+	}
+	sort.Strings(list)
+	for _, k := range list {
+		diff.Tags = append(diff.Tags, apiv1.TagDiff{Key: k, Deployed: stack.Spec.Tags[k], Rendered: tags[k]})
+	}
+
+	return diff, nil
+}
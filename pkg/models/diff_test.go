@@ -0,0 +1,65 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+func TestDiffStackNoChanges(t *testing.T) {
+	stack := &Stack{
+		Spec: StackSpec{
+			Content: `{"Resources":{"Bucket":{"Type":"AWS::S3::Bucket","Properties":{"BucketName":"test"}}}}`,
+			Tags:    map[string]string{CheckSumTag: "a", CreatedTag: "1"},
+		},
+	}
+	rendered := "Resources:\n  Bucket:\n    Properties:\n      BucketName: test\n    Type: AWS::S3::Bucket\n"
+
+	diff, err := DiffStack(stack, rendered, map[string]string{CheckSumTag: "a", CreatedTag: "2"})
+	require.NoError(t, err)
+	assert.False(t, diff.HasChanges())
+}
+
+func TestDiffStack(t *testing.T) {
+	stack := &Stack{
+		Spec: StackSpec{
+			Content: `{"Resources":{"Bucket":{"Properties":{"AccessControl":"Private","Tags":[1,2]}}}}`,
+			Tags:    map[string]string{CheckSumTag: "a", TemplateNameTag: "v1"},
+		},
+	}
+	rendered := "Resources:\n  Bucket:\n    Properties:\n      AccessControl: PublicRead\n      BucketName: test\n      Tags: [1]\n"
+
+	diff, err := DiffStack(stack, rendered, map[string]string{CheckSumTag: "a", ReconcileTag: "now"})
+	require.NoError(t, err)
+	assert.Equal(t, `~ Resources.Bucket.Properties.AccessControl: "Private" -> "PublicRead"
++ Resources.Bucket.Properties.BucketName: "test"
+- Resources.Bucket.Properties.Tags[1]: 2`, diff.Template)
+	assert.Equal(t, []apiv1.TagDiff{
+		{Key: ReconcileTag, Rendered: "now"},
+		{Key: TemplateNameTag, Deployed: "v1"},
+	}, diff.Tags)
+}
+
+func TestDiffStackInvalid(t *testing.T) {
+	_, err := DiffStack(&Stack{Spec: StackSpec{Content: "{"}}, "a: b", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// ErrorClassConflict indicates the stack is in a state the operation conflicts with, i.e. in progress
+	ErrorClassConflict = "Conflict"
+	// ErrorClassInvalidTemplate indicates the template or resource was rejected, it must change to succeed
+	ErrorClassInvalidTemplate = "InvalidTemplate"
+	// ErrorClassNotFound indicates the stack or an object it depends on does not exist
+	ErrorClassNotFound = "NotFound"
+	// ErrorClassQuotaExceeded indicates a quota or limit in the cloud or the namespace has been reached
+	ErrorClassQuotaExceeded = "QuotaExceeded"
+	// ErrorClassStackFailed indicates the stack has failed and the resource must change to recover it
+	ErrorClassStackFailed = "StackFailed"
+	// ErrorClassThrottled indicates the request was rate limited by the cloud provider
+	ErrorClassThrottled = "Throttled"
+	// ErrorClassTransient indicates a temporary failure which should succeed when retried
+	ErrorClassTransient = "Transient"
+	// ErrorClassUnauthorized indicates the controller is not permitted to perform the operation
+	ErrorClassUnauthorized = "Unauthorized"
+	// ErrorClassUnknown indicates the error has not been classified
+	ErrorClassUnknown = "Unknown"
+)
+
+// Error is an error classified into one of the error classes
+type Error struct {
+	// Class is the class of the error
+	Class string
+	// Code is the error code given by the cloud provider if any
+	Code string
+	// Err is the underlying error
+	Err error
+}
+
+// Error returns the message of the underlying error
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// NewError returns the error classified into the class
+func NewError(class, code string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Class: class, Code: code, Err: err}
+}
+
+// WrapError prefixes the message of the error, retaining the class of the error
+func WrapError(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	e := &Error{Class: GetErrorClass(err), Err: fmt.Errorf("%s: %s", message, err)}
+	if x, ok := err.(*Error); ok {
+		e.Code = x.Code
+	}
+
+	return e
+}
+
+// GetErrorClass returns the class of the error; classified errors carry their class, otherwise the
+// sentinel errors of the providers are known and anything else is unknown
+func GetErrorClass(err error) string {
+	if x, ok := err.(*Error); ok {
+		return x.Class
+	}
+
+	switch err {
+	case nil:
+		return ""
+	case ErrStackNotFound:
+		return ErrorClassNotFound
+	case ErrUnauthorized:
+		return ErrorClassUnauthorized
+	case ErrNotCancellable:
+		return ErrorClassConflict
+	case ErrOperationAborted, ErrOperationTimeout, context.DeadlineExceeded:
+		return ErrorClassTransient
+	}
+
+	return ErrorClassUnknown
+}
+
+// GetErrorReason returns a stable CamelCase reason for the error derived from its class
+func GetErrorReason(err error) string {
+	switch class := GetErrorClass(err); class {
+	case "":
+		return ""
+	case ErrorClassUnknown:
+		return "ReconcileFailed"
+	default:
+		return class
+	}
+}
+
+// IsErrorClass checks if the error is of the class
+func IsErrorClass(err error, class string) bool {
+	return err != nil && GetErrorClass(err) == class
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetErrorClass(t *testing.T) {
+	assert.Equal(t, "", GetErrorClass(nil))
+	assert.Equal(t, ErrorClassNotFound, GetErrorClass(ErrStackNotFound))
+	assert.Equal(t, ErrorClassTransient, GetErrorClass(ErrOperationTimeout))
+	assert.Equal(t, ErrorClassUnknown, GetErrorClass(errors.New("unknown")))
+	assert.Equal(t, ErrorClassThrottled, GetErrorClass(NewError(ErrorClassThrottled, "Throttling", errors.New("rate exceeded"))))
+}
+
+func TestWrapError(t *testing.T) {
+	err := WrapError(NewError(ErrorClassThrottled, "Throttling", errors.New("rate exceeded")), "unable to get stack")
+	assert.Equal(t, "unable to get stack: rate exceeded", err.Error())
+	assert.True(t, IsErrorClass(err, ErrorClassThrottled))
+	assert.Equal(t, "Throttling", err.(*Error).Code)
+
+	assert.True(t, IsErrorClass(WrapError(ErrStackNotFound, "unable to get stack"), ErrorClassNotFound))
+	assert.NoError(t, WrapError(nil, "unable to get stack"))
+}
+
+func TestGetErrorReason(t *testing.T) {
+	assert.Equal(t, "", GetErrorReason(nil))
+	assert.Equal(t, "ReconcileFailed", GetErrorReason(errors.New("unknown")))
+	assert.Equal(t, "InvalidTemplate", GetErrorReason(NewError(ErrorClassInvalidTemplate, "", errors.New("invalid"))))
+}
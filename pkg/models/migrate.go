@@ -0,0 +1,104 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const (
+	// EventTemplateDeprecated is the event reason for resources using a deprecated template
+	EventTemplateDeprecated = "TemplateDeprecated"
+	// EventTemplateMigrated is the event reason for resources migrated to a successor template
+	EventTemplateMigrated = "TemplateMigrated"
+)
+
+// WantsMigration checks if the resource has opted into migration to the successor of the template
+func WantsMigration(resource *apiv1.CloudResource, template *apiv1.CloudTemplate) bool {
+	if !template.Spec.Deprecated || template.Spec.Successor == nil {
+		return false
+	}
+
+	return resource.GetAnnotations()[MigrateAnnotation] == "true"
+}
+
+// MigrateResource returns a copy of the resource pointing at the successor template with the parameters
+// mapped; unmapped parameters are kept when the successor declares them. The stack name is derived from
+// the resource, so the existing stack is updated rather than replaced.
+func MigrateResource(resource *apiv1.CloudResource, template, successor *apiv1.CloudTemplate) (*apiv1.CloudResource, error) {
+	if template.Spec.Successor == nil || template.Spec.Successor.Name != successor.Name {
+		return nil, fmt.Errorf("cloud template: %s is not the successor of: %s", successor.Name, template.Name)
+	}
+
+	declared := make(map[string]bool, 0)
+	for _, x := range successor.Spec.Parameters {
+		declared[x.Name] = true
+	}
+	current := make(map[string]apiv1.Parameter, 0)
+	for _, x := range resource.Spec.Parameters {
+		current[x.Name] = x
+	}
+
+	migrated := resource.DeepCopy()
+	migrated.Spec.TemplateName = successor.Name
+	migrated.Spec.Parameters = nil
+
+	// @step: apply the parameter mappings of the deprecated template
+	mapped := make(map[string]bool, 0)
+	for _, x := range template.Spec.Successor.Parameters {
+		mapped[x.From] = true
+		if !declared[x.To] {
+			return nil, fmt.Errorf("parameter: %s is not declared by the successor template: %s", x.To, successor.Name)
+		}
+		if x.Value != nil {
+			value := *x.Value
+			migrated.Spec.Parameters = append(migrated.Spec.Parameters, apiv1.Parameter{Name: x.To, Value: &value})
+			continue
+		}
+		if param, found := current[x.From]; found {
+			param.Name = x.To
+			migrated.Spec.Parameters = append(migrated.Spec.Parameters, *param.DeepCopy())
+		}
+	}
+
+	// @step: carry over the unmapped parameters the successor understands
+	for _, x := range resource.Spec.Parameters {
+		if mapped[x.Name] || !declared[x.Name] || migrated.HasParameter(x.Name) {
+			continue
+		}
+		migrated.Spec.Parameters = append(migrated.Spec.Parameters, *x.DeepCopy())
+	}
+
+	if missing := migrated.GetMissingParameters(successor); len(missing) > 0 {
+		return nil, fmt.Errorf("parameters required by the successor template missing: %s", strings.Join(missing, ","))
+	}
+
+	// @step: record the migration and clear the opt-in
+	annotations := make(map[string]string, 0)
+	for k, v := range resource.GetAnnotations() {
+		if k != MigrateAnnotation {
+			annotations[k] = v
+		}
+	}
+	annotations[MigratedFromAnnotation] = template.Name
+	migrated.Annotations = annotations
+
+	return migrated, nil
+}
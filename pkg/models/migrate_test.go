@@ -0,0 +1,108 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+func newTestMigration() (*apiv1.CloudResource, *apiv1.CloudTemplate, *apiv1.CloudTemplate) {
+	private := "private"
+	secret := "bucket-name"
+	resource := &apiv1.CloudResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "bucket",
+			Namespace:   "apps",
+			Annotations: map[string]string{MigrateAnnotation: "true", "team": "storage"},
+		},
+		Spec: apiv1.CloudResourceSpec{
+			TemplateName: "s3.bucket.v1",
+			Parameters: []apiv1.Parameter{
+				{Name: "bucket", SecretName: &secret},
+				{Name: "versioning", Value: &private},
+				{Name: "legacy", Value: &private},
+			},
+		},
+	}
+	template := &apiv1.CloudTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3.bucket.v1"},
+		Spec: apiv1.TemplateSpec{
+			Deprecated: true,
+			Successor: &apiv1.TemplateSuccessor{
+				Name: "s3.bucket.v2",
+				Parameters: []apiv1.ParameterMapping{
+					{From: "bucket", To: "name"},
+					{To: "acl", Value: &private},
+				},
+			},
+		},
+	}
+	successor := &apiv1.CloudTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3.bucket.v2"},
+		Spec: apiv1.TemplateSpec{
+			Parameters: []apiv1.Parameter{{Name: "name"}, {Name: "acl"}, {Name: "versioning"}},
+		},
+	}
+
+	return resource, template, successor
+}
+
+func TestMigrateResource(t *testing.T) {
+	resource, template, successor := newTestMigration()
+	assert.True(t, WantsMigration(resource, template))
+
+	migrated, err := MigrateResource(resource, template, successor)
+	require.NoError(t, err)
+	assert.Equal(t, "s3.bucket.v2", migrated.Spec.TemplateName)
+	assert.Equal(t, "bucket", migrated.Name)
+
+	var names []string
+	for _, x := range migrated.Spec.Parameters {
+		names = append(names, x.Name)
+	}
+	assert.Equal(t, []string{"name", "acl", "versioning"}, names)
+	assert.Equal(t, "bucket-name", *migrated.Spec.Parameters[0].SecretName)
+	assert.Equal(t, "private", *migrated.Spec.Parameters[1].Value)
+
+	assert.Equal(t, map[string]string{MigratedFromAnnotation: "s3.bucket.v1", "team": "storage"}, migrated.Annotations)
+	assert.False(t, WantsMigration(migrated, template))
+
+	// @step: the original resource should not be altered
+	assert.Equal(t, "s3.bucket.v1", resource.Spec.TemplateName)
+}
+
+func TestMigrateResourceMissingParameters(t *testing.T) {
+	resource, template, successor := newTestMigration()
+	successor.Spec.Parameters = append(successor.Spec.Parameters, apiv1.Parameter{Name: "region"})
+
+	_, err := MigrateResource(resource, template, successor)
+	assert.Error(t, err)
+}
+
+func TestMigrateResourceUndeclaredParameter(t *testing.T) {
+	resource, template, successor := newTestMigration()
+	successor.Spec.Parameters = successor.Spec.Parameters[1:]
+
+	_, err := MigrateResource(resource, template, successor)
+	assert.Error(t, err)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+// SecretGetter retrieves the data of a kubernetes secret referenced by a resource parameter
+type SecretGetter func(name, namespace string) (map[string]string, error)
+
+// MakeResourceModel is resposible for consolidating the parameteres, secrets and attributes
+func MakeResourceModel(template *apiv1.CloudTemplate, resource *apiv1.CloudResource, secrets SecretGetter) (map[string]string, error) {
+	values := make(map[string]string, 0)
+
+	{
+		// @step: in the deletion policy and retention
+		resource.ApplyDefaults(template)
+	}
+
+	{
+		// @check if no default is set and the parameter is required that parameter is set
+		if missing := resource.GetMissingParameters(template); len(missing) > 0 {
+			return values, fmt.Errorf("resource parameter: '%s' is required", missing[0])
+		}
+		// @step: copy the default parameters from the template into the model
+		for _, x := range template.Spec.Parameters {
+			if x.Value != nil {
+				values[x.Name] = *x.Value
+			}
+		}
+		// @step: we need to iterate the resource parameters and pull in the values or the kubernetes secrets
+		for _, x := range resource.Spec.Parameters {
+			// @check if this a static parameter
+			if x.Value != nil {
+				values[x.Name] = *x.Value
+				continue
+			}
+			if x.SecretName != nil {
+				// @step: pull the kubernetes secret from the resource's namespace
+				secret, err := secrets(*x.SecretName, resource.Namespace)
+				if err != nil {
+					return values, fmt.Errorf("paramater: '%s' unable to pull from kubernetes secret: %s", x.Name, err)
+				}
+				switch len(secret) {
+				case 0:
+					return values, fmt.Errorf("parameter: '%s' kubernetes secret has no value", x.Name)
+				case 1:
+					keys := reflect.ValueOf(secret).MapKeys()
+					values[x.Name] = fmt.Sprintf("%s", keys[0])
+				default:
+					return values, fmt.Errorf("parameter: '%s' kubernetes secret has multiple keys", x.Name)
+				}
+				continue
+			}
+			// @step: thrown an error and nothing has been set
+			return values, fmt.Errorf("resource parameter: '%s' has no value or kubernetes secret set", x.Name)
+		}
+	}
+
+	{
+		// @step: inject the secrets from the template
+		for _, x := range template.Spec.Secrets {
+			resource.AddSecret(x)
+		}
+	}
+
+	return values, nil
+}
+
+// GetResourceChecksum is responsible for checking if the resource parameters have changed
+func GetResourceChecksum(resource *apiv1.CloudResource) string {
+	h := md5.New()
+	for _, x := range resource.Spec.Parameters {
+		io.WriteString(h, x.Name)
+		if x.SecretName != nil {
+			io.WriteString(h, *x.SecretName)
+		}
+		if x.Value != nil {
+			io.WriteString(h, *x.Value)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MakeStackTags returns the tags the stack of the resource is created with
+func MakeStackTags(resource *apiv1.CloudResource, provider string) map[string]string {
+	tags := map[string]string{
+		CheckSumTag:     GetResourceChecksum(resource),
+		CreatedTag:      fmt.Sprintf("%d", time.Now().Unix()),
+		NamespaceTag:    resource.Namespace,
+		ProviderNameTag: provider,
+		ResourceNameTag: resource.Name,
+		TemplateNameTag: resource.Spec.TemplateName,
+	}
+	if resource.Spec.Retention != nil {
+		tags[RetentionTag] = fmt.Sprintf("%d", resource.Spec.Retention.Duration)
+	}
+	if request, found := resource.GetAnnotations()[ReconcileAnnotation]; found {
+		tags[ReconcileTag] = request
+	}
+
+	return tags
+}
+
+// GetStackName is the default naming convertion for all formation stacks
+func GetStackName(name, namespace string) string {
+	return fmt.Sprintf("stacks-%s-%s", namespace, name)
+}
+
+// ValidateResourceParameters checks the parameters of the resource against those declared by the
+// template, i.e. all required parameters are set and no undeclared parameters are given
+func ValidateResourceParameters(resource *apiv1.CloudResource, template *apiv1.CloudTemplate) field.ErrorList {
+	var errs field.ErrorList
+
+	path := field.NewPath("spec").Key("parameters")
+	for _, name := range resource.GetMissingParameters(template) {
+		errs = append(errs, field.Required(path.Key(name), "parameter is required by the template"))
+	}
+	declared := make(map[string]bool, len(template.Spec.Parameters))
+	for _, x := range template.Spec.Parameters {
+		declared[x.Name] = true
+	}
+	for i, x := range resource.Spec.Parameters {
+		if !declared[x.Name] {
+			errs = append(errs, field.Invalid(path.Index(i), x.Name, "parameter is not declared by the template"))
+		}
+	}
+
+	return errs
+}
+
+// CheckSecretMappings checks the secret mappings on the resource can be satisfied by the template
+func CheckSecretMappings(resource *apiv1.CloudResource, template *apiv1.CloudTemplate) []string {
+	var reasons []string
+
+	names := make(map[string]bool, 0)
+	for _, x := range resource.Spec.Secrets {
+		if names[x.Name] {
+			reasons = append(reasons, fmt.Sprintf("secret: %s is defined more than once", x.Name))
+		}
+		names[x.Name] = true
+
+		keys := make(map[string]bool, 0)
+		for _, v := range x.Values {
+			if keys[v.Key] {
+				reasons = append(reasons, fmt.Sprintf("secret: %s has duplicate key: %s", x.Name, v.Key))
+			}
+			keys[v.Key] = true
+
+			if v.Type == apiv1.SecretTypeCredential && !template.Spec.Credentials {
+				reasons = append(reasons, fmt.Sprintf("secret: %s key: %s references credentials but the template does not provide any", x.Name, v.Key))
+			}
+		}
+	}
+
+	return reasons
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+func TestMakeResourceModel(t *testing.T) {
+	acl, bucket, secret := "Private", "test", "bucket"
+	template := &apiv1.CloudTemplate{
+		Spec: apiv1.TemplateSpec{
+			Parameters: []apiv1.Parameter{{Name: "bucket"}, {Name: "acl", Value: &acl}, {Name: "owner"}},
+		},
+	}
+	resource := &apiv1.CloudResource{
+		Spec: apiv1.CloudResourceSpec{
+			Parameters: []apiv1.Parameter{{Name: "bucket", Value: &bucket}, {Name: "owner", SecretName: &secret}},
+		},
+	}
+	secrets := func(name, namespace string) (map[string]string, error) {
+		if name != secret {
+			return nil, fmt.Errorf("not found")
+		}
+		return map[string]string{"team": ""}, nil
+	}
+
+	model, err := MakeResourceModel(template, resource, secrets)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"acl": "Private", "bucket": "test", "owner": "team"}, model)
+
+	resource.Spec.Parameters = resource.Spec.Parameters[:1]
+	_, err = MakeResourceModel(template, resource, secrets)
+	assert.Error(t, err)
+}
+
+func TestValidateResourceParameters(t *testing.T) {
+	value := "test"
+	template := &apiv1.CloudTemplate{
+		Spec: apiv1.TemplateSpec{
+			Parameters: []apiv1.Parameter{{Name: "bucket"}, {Name: "acl", Value: &value}},
+		},
+	}
+	resource := &apiv1.CloudResource{
+		Spec: apiv1.CloudResourceSpec{
+			Parameters: []apiv1.Parameter{{Name: "acl", Value: &value}, {Name: "unknown", Value: &value}},
+		},
+	}
+
+	errs := ValidateResourceParameters(resource, template)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "spec[parameters][bucket]", errs[0].Field)
+	assert.Equal(t, "spec[parameters][1]", errs[1].Field)
+}
+
+func TestGetResourceChecksum(t *testing.T) {
+	a, b := "a", "b"
+	resource := &apiv1.CloudResource{
+		Spec: apiv1.CloudResourceSpec{Parameters: []apiv1.Parameter{{Name: "bucket", Value: &a}}},
+	}
+	before := GetResourceChecksum(resource)
+	assert.Equal(t, before, GetResourceChecksum(resource.DeepCopy()))
+	resource.Spec.Parameters[0].Value = &b
+	assert.NotEqual(t, before, GetResourceChecksum(resource))
+}
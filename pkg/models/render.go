@@ -0,0 +1,123 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const (
+	// RenderKeyCloud is the key for the discovery lookups in engines without functions
+	RenderKeyCloud = "Cloud"
+	// RenderKeyCluster is the key for the cluster in the render context
+	RenderKeyCluster = "Cluster"
+	// RenderKeyNamespace is the key for the namespace in the render context
+	RenderKeyNamespace = "Namespace"
+	// RenderKeyParams is the key for the parameters in the render context
+	RenderKeyParams = "Params"
+	// RenderKeyResource is the key for the resource in the render context
+	RenderKeyResource = "Resource"
+	// RenderKeyTemplate is the key for the template in the render context
+	RenderKeyTemplate = "Template"
+)
+
+// RenderContext is the data made available to a template when rendering
+type RenderContext struct {
+	// Cluster is the cluster the resource is provisioned from
+	Cluster ClusterContext
+	// Namespace is the namespace of the resource
+	Namespace NamespaceContext
+	// Params are the resolved parameters of the resource
+	Params map[string]string
+	// Resource is the metadata of the cloud resource
+	Resource ObjectContext
+	// Template is the metadata of the cloud template
+	Template ObjectContext
+}
+
+// ClusterContext is the cluster as presented to the templates
+type ClusterContext struct {
+	// Name is the name of the cluster
+	Name string
+	// Region is the region of the cluster
+	Region string
+}
+
+// NamespaceContext is the namespace as presented to the templates
+type NamespaceContext struct {
+	// Name is the name of the namespace
+	Name string
+	// Labels are the labels on the namespace
+	Labels map[string]string
+}
+
+// ObjectContext is the metadata of a kubernetes object as presented to the templates
+type ObjectContext struct {
+	// Name is the name of the object
+	Name string
+	// Namespace is the namespace of the object if any
+	Namespace string
+	// Labels are the labels on the object
+	Labels map[string]string
+	// Annotations are the annotations on the object
+	Annotations map[string]string
+}
+
+// NewRenderContext creates the render context from the create options
+func NewRenderContext(options *CreateOptions, config *ProviderConfig) *RenderContext {
+	data := &RenderContext{
+		Namespace: NamespaceContext{Labels: options.NamespaceLabels},
+		Params:    options.Context,
+	}
+	if config != nil {
+		data.Cluster = ClusterContext{Name: config.ClusterName, Region: config.Region}
+	}
+	if options.Resource != nil {
+		data.Namespace.Name = options.Resource.Namespace
+		data.Resource = ObjectContext{
+			Annotations: options.Resource.Annotations,
+			Labels:      options.Resource.Labels,
+			Name:        options.Resource.Name,
+			Namespace:   options.Resource.Namespace,
+		}
+	}
+	if options.Template != nil {
+		data.Template = ObjectContext{
+			Annotations: options.Template.Annotations,
+			Labels:      options.Template.Labels,
+			Name:        options.Template.Name,
+		}
+	}
+
+	return data
+}
+
+// Values returns the context as the data for a template; the parameters are also placed at the
+// top level so templates can continue to use {{ .param }}, the reserved keys take precedence
+func (r *RenderContext) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(r.Params)+len(apiv1.ReservedParameters))
+	for k, v := range r.Params {
+		values[k] = v
+	}
+	values[RenderKeyCluster] = r.Cluster
+	values[RenderKeyNamespace] = r.Namespace
+	values[RenderKeyParams] = r.Params
+	values[RenderKeyResource] = r.Resource
+	values[RenderKeyTemplate] = r.Template
+
+	return values
+}
@@ -34,3 +34,31 @@ type Network struct {
 	// AvailabilityZone is the availability zone
 	AvailabilityZone string
 }
+
+// SecurityGroup is a security group
+type SecurityGroup struct {
+	Object
+	// Description is the description of the group
+	Description string
+	// NetworkID is the network the group resides in
+	NetworkID string
+}
+
+// RouteTable is a network route table
+type RouteTable struct {
+	Object
+	// NetworkID is the network the route table resides in
+	NetworkID string
+	// Subnets are the ids of subnets associated to the table
+	Subnets []string
+}
+
+// HostedZone is a dns zone
+type HostedZone struct {
+	// ID is the id of the zone
+	ID string
+	// Name is the domain of the zone
+	Name string
+	// Private indicates the zone is private
+	Private bool
+}
@@ -34,6 +34,11 @@ func (s *Stack) CheckSum() string {
 	return s.Spec.Tags[CheckSumTag]
 }
 
+// Reconciled returns the reconcile request of the resource the stack was last updated for
+func (s *Stack) Reconciled() string {
+	return s.Spec.Tags[ReconcileTag]
+}
+
 // RequiresDeletion checks if the stack should be deleted
 func (s *Stack) RequiresDeletion() bool {
 	if !s.HasDeleteTag() {
@@ -47,3 +52,18 @@ func (s *Stack) RequiresDeletion() bool {
 func (s *Stack) ExpiresIn() string {
 	return s.Spec.DeleteOn.Sub(time.Now()).String()
 }
+
+// IsInProgress checks if an operation is in progress on the stack
+func (s StackStatus) IsInProgress() bool {
+	return IsPhaseInProgress(s.Phase)
+}
+
+// IsPhaseInProgress checks if the phase is one of an operation in progress on the stack
+func IsPhaseInProgress(phase string) bool {
+	switch phase {
+	case PhaseCreating, PhaseDeleting, PhaseRollingBack, PhaseUpdating:
+		return true
+	}
+
+	return false
+}
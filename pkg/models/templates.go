@@ -0,0 +1,190 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const (
+	// DependencyFragment is the prefix of a fragment dependency
+	DependencyFragment = "fragment/"
+	// DependencyTemplate is the prefix of a template dependency
+	DependencyTemplate = "template/"
+)
+
+// TemplateCycleError indicates the inheritance of the templates forms a cycle
+type TemplateCycleError struct {
+	// Chain is the names of the templates forming the cycle
+	Chain []string
+}
+
+// Error returns the error message
+func (e *TemplateCycleError) Error() string {
+	return fmt.Sprintf("template inheritance cycle: %s", strings.Join(e.Chain, " -> "))
+}
+
+// IsTemplateCycle checks if the error is a cycle in the template inheritance
+func IsTemplateCycle(err error) bool {
+	_, ok := err.(*TemplateCycleError)
+
+	return ok
+}
+
+// TemplateGetter retrieves a cloud template by name
+type TemplateGetter func(name string) (*apiv1.CloudTemplate, error)
+
+// FragmentGetter retrieves a template fragment by name
+type FragmentGetter func(name string) (*apiv1.CloudTemplateFragment, error)
+
+// ResolvedTemplate is a cloud template with the inheritance chain and fragments resolved
+type ResolvedTemplate struct {
+	// Template is the template with the parameters, secrets and defaults of the bases merged
+	Template *apiv1.CloudTemplate
+	// Partials are the contents of the fragments and bases, parsed in order before the content
+	Partials []string
+	// Dependencies are the fragments and templates the template depends on i.e. fragment/tags
+	Dependencies []string
+}
+
+// DependsOn checks if the resolved template depends on the fragment or template
+func (r *ResolvedTemplate) DependsOn(dependency string) bool {
+	for _, x := range r.Dependencies {
+		if x == dependency {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResolveTemplate walks the inheritance chain of the template, returning the merged template and the
+// partials to parse before the content; the fragments are parsed first, followed by the bases from the
+// root down so the define blocks of a template override those of its bases
+func ResolveTemplate(template *apiv1.CloudTemplate, templates TemplateGetter, fragments FragmentGetter) (*ResolvedTemplate, error) {
+	// @step: build the chain from the template up to the root
+	chain := []*apiv1.CloudTemplate{template}
+	visited := map[string]bool{template.Name: true}
+	names := []string{template.Name}
+
+	for current := template; current.Spec.Extends != ""; {
+		base := current.Spec.Extends
+		names = append(names, base)
+		if visited[base] {
+			return nil, &TemplateCycleError{Chain: names}
+		}
+		visited[base] = true
+
+		found, err := templates(base)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve base template: %s, error: %s", base, err)
+		}
+		if found.Spec.GetEngine() != apiv1.EngineGoTemplate {
+			return nil, fmt.Errorf("base template: %s does not use the gotemplate engine", base)
+		}
+		chain = append(chain, found)
+		current = found
+	}
+
+	resolved := &ResolvedTemplate{Template: template.DeepCopy()}
+	for _, x := range chain[1:] {
+		resolved.Dependencies = append(resolved.Dependencies, DependencyTemplate+x.Name)
+	}
+
+	// @step: collect the fragments of the chain from the root down, each only once
+	seen := make(map[string]bool, 0)
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, name := range chain[i].Spec.Fragments {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			fragment, err := fragments(name)
+			if err != nil {
+				return nil, fmt.Errorf("unable to retrieve template fragment: %s, error: %s", name, err)
+			}
+			resolved.Partials = append(resolved.Partials, fragment.Spec.Content)
+			resolved.Dependencies = append(resolved.Dependencies, DependencyFragment+name)
+		}
+	}
+
+	// @step: merge the bases from the root down into the template
+	spec := &resolved.Template.Spec
+	for i := len(chain) - 1; i > 0; i-- {
+		base := chain[i].Spec
+		resolved.Partials = append(resolved.Partials, base.Content)
+
+		if spec.Format == "" {
+			spec.Format = base.Format
+		}
+		if spec.Retention == nil && base.Retention != nil {
+			spec.Retention = base.Retention.DeepCopy()
+		}
+		if spec.DeleteOn == nil && base.DeleteOn != nil {
+			policy := *base.DeleteOn
+			spec.DeleteOn = &policy
+		}
+		if spec.Timeout == nil && base.Timeout != nil {
+			spec.Timeout = base.Timeout.DeepCopy()
+		}
+		spec.Credentials = spec.Credentials || base.Credentials
+	}
+	spec.Parameters = mergeParameters(chain)
+	spec.Secrets = mergeSecrets(chain)
+
+	return resolved, nil
+}
+
+// mergeParameters merges the parameters of the chain, a template overriding those of its bases
+func mergeParameters(chain []*apiv1.CloudTemplate) []apiv1.Parameter {
+	var list []apiv1.Parameter
+	index := make(map[string]int, 0)
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, x := range chain[i].Spec.Parameters {
+			if j, found := index[x.Name]; found {
+				list[j] = *x.DeepCopy()
+				continue
+			}
+			index[x.Name] = len(list)
+			list = append(list, *x.DeepCopy())
+		}
+	}
+
+	return list
+}
+
+// mergeSecrets merges the secrets of the chain, a template overriding those of its bases
+func mergeSecrets(chain []*apiv1.CloudTemplate) []apiv1.Secret {
+	var list []apiv1.Secret
+	index := make(map[string]int, 0)
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, x := range chain[i].Spec.Secrets {
+			if j, found := index[x.Name]; found {
+				list[j] = *x.DeepCopy()
+				continue
+			}
+			index[x.Name] = len(list)
+			list = append(list, *x.DeepCopy())
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+func newTestGetters(templates []*apiv1.CloudTemplate, fragments []*apiv1.CloudTemplateFragment) (TemplateGetter, FragmentGetter) {
+	return func(name string) (*apiv1.CloudTemplate, error) {
+			for _, x := range templates {
+				if x.Name == name {
+					return x, nil
+				}
+			}
+			return nil, fmt.Errorf("not found")
+		}, func(name string) (*apiv1.CloudTemplateFragment, error) {
+			for _, x := range fragments {
+				if x.Name == name {
+					return x, nil
+				}
+			}
+			return nil, fmt.Errorf("not found")
+		}
+}
+
+func TestResolveTemplate(t *testing.T) {
+	base := &apiv1.CloudTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "base"},
+		Spec: apiv1.TemplateSpec{
+			Content:    "base",
+			Format:     apiv1.FormatYAML,
+			Fragments:  []string{"tags"},
+			Parameters: []apiv1.Parameter{{Name: "bucket"}, {Name: "acl", Description: "base"}},
+			Retention:  &metav1.Duration{Duration: time.Minute},
+			Secrets:    []apiv1.Secret{{Name: "bucket", Description: "base"}},
+		},
+	}
+	child := &apiv1.CloudTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "child"},
+		Spec: apiv1.TemplateSpec{
+			Content:    "child",
+			Extends:    "base",
+			Fragments:  []string{"policy", "tags"},
+			Parameters: []apiv1.Parameter{{Name: "acl", Description: "child"}},
+			Secrets:    []apiv1.Secret{{Name: "bucket", Description: "child"}},
+		},
+	}
+	fragments := []*apiv1.CloudTemplateFragment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "tags"}, Spec: apiv1.TemplateFragmentSpec{Content: "tags"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "policy"}, Spec: apiv1.TemplateFragmentSpec{Content: "policy"}},
+	}
+	templates, fragmentGetter := newTestGetters([]*apiv1.CloudTemplate{base, child}, fragments)
+
+	resolved, err := ResolveTemplate(child, templates, fragmentGetter)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tags", "policy", "base"}, resolved.Partials)
+	assert.Equal(t, []string{"template/base", "fragment/tags", "fragment/policy"}, resolved.Dependencies)
+	assert.True(t, resolved.DependsOn(DependencyFragment+"policy"))
+
+	spec := resolved.Template.Spec
+	assert.Equal(t, "child", spec.Content)
+	assert.Equal(t, apiv1.FormatYAML, spec.Format)
+	assert.Equal(t, time.Minute, spec.Retention.Duration)
+	assert.Equal(t, []apiv1.Parameter{{Name: "bucket"}, {Name: "acl", Description: "child"}}, spec.Parameters)
+	assert.Equal(t, []apiv1.Secret{{Name: "bucket", Description: "child"}}, spec.Secrets)
+
+	// @step: the original template should not be altered
+	assert.Empty(t, child.Spec.Format)
+}
+
+func TestResolveTemplateCycle(t *testing.T) {
+	a := &apiv1.CloudTemplate{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: apiv1.TemplateSpec{Extends: "b"}}
+	b := &apiv1.CloudTemplate{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: apiv1.TemplateSpec{Extends: "c"}}
+	c := &apiv1.CloudTemplate{ObjectMeta: metav1.ObjectMeta{Name: "c"}, Spec: apiv1.TemplateSpec{Extends: "a"}}
+	templates, fragments := newTestGetters([]*apiv1.CloudTemplate{a, b, c}, nil)
+
+	_, err := ResolveTemplate(a, templates, fragments)
+	require.Error(t, err)
+	assert.True(t, IsTemplateCycle(err))
+	assert.Equal(t, "template inheritance cycle: a -> b -> c -> a", err.Error())
+}
+
+func TestResolveTemplateMissing(t *testing.T) {
+	a := &apiv1.CloudTemplate{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: apiv1.TemplateSpec{Fragments: []string{"missing"}}}
+	templates, fragments := newTestGetters(nil, nil)
+
+	_, err := ResolveTemplate(a, templates, fragments)
+	assert.Error(t, err)
+	assert.False(t, IsTemplateCycle(err))
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+// TemplateTestOutcome is the outcome of running a test case of a template
+type TemplateTestOutcome struct {
+	// Name is the name of the test case
+	Name string
+	// Rendered is the rendered template, empty if the render failed
+	Rendered string
+	// Failures are the reasons the test case failed
+	Failures []string
+}
+
+// Passed checks if the test case passed
+func (t *TemplateTestOutcome) Passed() bool {
+	return len(t.Failures) <= 0
+}
+
+// GetTemplateTestResults converts the outcomes of the test cases into the template status
+func GetTemplateTestResults(outcomes []TemplateTestOutcome) []apiv1.TemplateTestResult {
+	var list []apiv1.TemplateTestResult
+	for _, x := range outcomes {
+		list = append(list, apiv1.TemplateTestResult{
+			Message: strings.Join(x.Failures, "; "),
+			Name:    x.Name,
+			Passed:  x.Passed(),
+		})
+	}
+
+	return list
+}
+
+// DiffLines returns the lines which differ between the expected and actual content, prefixed with
+// - for the expected and + for the actual; empty when the content matches ignoring trailing whitespace
+func DiffLines(expected, actual string) string {
+	a := strings.Split(strings.TrimRight(expected, " \n"), "\n")
+	b := strings.Split(strings.TrimRight(actual, " \n"), "\n")
+
+	var diff []string
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(a):
+			diff = append(diff, fmt.Sprintf("+%d: %s", i+1, b[i]))
+		case i >= len(b):
+			diff = append(diff, fmt.Sprintf("-%d: %s", i+1, a[i]))
+		case strings.TrimRight(a[i], " ") != strings.TrimRight(b[i], " "):
+			diff = append(diff, fmt.Sprintf("-%d: %s", i+1, a[i]), fmt.Sprintf("+%d: %s", i+1, b[i]))
+		}
+	}
+
+	return strings.Join(diff, "\n")
+}
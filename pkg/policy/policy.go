@@ -0,0 +1,279 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/client-go/util/jsonpath"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+// Violation is a breach of a cloud policy by a resource in the template
+type Violation struct {
+	// Policy is the name of the policy violated
+	Policy string
+	// Resource is the logical name of the resource in the template
+	Resource string
+	// Message is a description of the violation
+	Message string
+}
+
+// String returns a description of the violation
+func (v Violation) String() string {
+	return fmt.Sprintf("policy: %s, resource: %s, %s", v.Policy, v.Resource, v.Message)
+}
+
+// ViolationError is returned when a template violates one or more policies
+type ViolationError struct {
+	// Violations is the list of violations
+	Violations []Violation
+}
+
+// Error returns the violations as an error message
+func (e *ViolationError) Error() string {
+	var list []string
+	for _, x := range e.Violations {
+		list = append(list, x.String())
+	}
+
+	return fmt.Sprintf("template violates cloud policies: %s", strings.Join(list, "; "))
+}
+
+// IsViolation checks if the error is a policy violation
+func IsViolation(err error) bool {
+	_, ok := err.(*ViolationError)
+
+	return ok
+}
+
+// Evaluate checks the rendered template against the policies and returns a ViolationError
+// if any of the resources in the template are in breach
+func Evaluate(policies []apiv1.CloudPolicy, content string) error {
+	resources, err := getResources(content)
+	if err != nil {
+		return err
+	}
+
+	// @step: iterate the resources in a consistent order
+	var names []string
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []Violation
+	for _, x := range policies {
+		for _, name := range names {
+			list, err := evaluate(&x, name, resources[name])
+			if err != nil {
+				return fmt.Errorf("unable to evaluate policy: %s, error: %s", x.Name, err)
+			}
+			violations = append(violations, list...)
+		}
+	}
+	if len(violations) > 0 {
+		return &ViolationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// evaluate checks the resource against a single policy
+func evaluate(policy *apiv1.CloudPolicy, name string, resource map[string]interface{}) ([]Violation, error) {
+	var list []Violation
+
+	kind, _ := resource["Type"].(string)
+	violation := func(message string, args ...interface{}) {
+		list = append(list, Violation{
+			Message:  fmt.Sprintf(message, args...),
+			Policy:   policy.Name,
+			Resource: name,
+		})
+	}
+
+	// @check the resource type is permitted
+	if len(policy.Spec.AllowedTypes) > 0 && !matchesType(policy.Spec.AllowedTypes, kind) {
+		violation("resource type: %s is not in the allowed types", kind)
+	}
+	if matchesType(policy.Spec.DeniedTypes, kind) {
+		violation("resource type: %s is denied", kind)
+	}
+
+	// @step: evaluate any constraints on the resource properties
+	for _, x := range policy.Spec.Constraints {
+		if x.ResourceType != "" && !matchesType([]string{x.ResourceType}, kind) {
+			continue
+		}
+		found, err := findValues(x.Path, resource)
+		if err != nil {
+			return list, err
+		}
+		ok, err := checkConstraint(x, found)
+		if err != nil {
+			return list, err
+		}
+		if ok {
+			continue
+		}
+		message := x.Message
+		if message == "" {
+			message = fmt.Sprintf("%s %s %s", x.Path, x.Operator, strings.Join(x.Values, ","))
+		}
+		violation("constraint: %s failed: %s", x.Name, message)
+	}
+
+	return list, nil
+}
+
+// checkConstraint checks the values from the resource satisfy the constraint; a constraint
+// comparing values is not applicable if the path does not exist in the resource
+func checkConstraint(constraint apiv1.PolicyConstraint, values []string) (bool, error) {
+	switch constraint.Operator {
+	case apiv1.OperatorExists:
+		return len(values) > 0, nil
+	case apiv1.OperatorNotExists:
+		return len(values) <= 0, nil
+	}
+	if len(constraint.Values) <= 0 {
+		return false, fmt.Errorf("constraint: %s has no values", constraint.Name)
+	}
+	expected := constraint.Values[0]
+
+	for _, x := range values {
+		var ok bool
+		switch constraint.Operator {
+		case apiv1.OperatorEquals:
+			ok = x == expected
+		case apiv1.OperatorNotEquals:
+			ok = x != expected
+		case apiv1.OperatorIn:
+			ok = contains(constraint.Values, x)
+		case apiv1.OperatorNotIn:
+			ok = !contains(constraint.Values, x)
+		case apiv1.OperatorMatches:
+			matched, err := regexp.MatchString(expected, x)
+			if err != nil {
+				return false, fmt.Errorf("constraint: %s has invalid regexp: %s", constraint.Name, err)
+			}
+			ok = matched
+		case apiv1.OperatorLessThan, apiv1.OperatorGreaterThan:
+			value, err := strconv.ParseFloat(x, 64)
+			if err != nil {
+				return false, nil
+			}
+			limit, err := strconv.ParseFloat(expected, 64)
+			if err != nil {
+				return false, fmt.Errorf("constraint: %s value: %s is not numeric", constraint.Name, expected)
+			}
+			ok = value < limit
+			if constraint.Operator == apiv1.OperatorGreaterThan {
+				ok = value > limit
+			}
+		default:
+			return false, fmt.Errorf("constraint: %s has unsupported operator: %s", constraint.Name, constraint.Operator)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// findValues returns the values found at the jsonpath in the resource
+func findValues(expression string, resource map[string]interface{}) ([]string, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = fmt.Sprintf("{%s}", expression)
+	}
+	jp := jsonpath.New("constraint")
+	jp.AllowMissingKeys(true)
+	if err := jp.Parse(expression); err != nil {
+		return nil, fmt.Errorf("invalid path: %s, error: %s", expression, err)
+	}
+	results, err := jp.FindResults(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []string
+	for _, x := range results {
+		for _, v := range x {
+			if !v.IsValid() || !v.CanInterface() || v.Interface() == nil {
+				continue
+			}
+			switch value := v.Interface().(type) {
+			case string:
+				list = append(list, value)
+			case []interface{}:
+				for _, e := range value {
+					list = append(list, fmt.Sprintf("%v", e))
+				}
+			default:
+				list = append(list, fmt.Sprintf("%v", value))
+			}
+		}
+	}
+
+	return list, nil
+}
+
+// getResources decodes the resources section from a rendered template
+func getResources(content string) (map[string]map[string]interface{}, error) {
+	encoded, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the rendered template: %s", err)
+	}
+	document := struct {
+		Resources map[string]map[string]interface{} `json:"Resources"`
+	}{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, fmt.Errorf("unable to decode the rendered template: %s", err)
+	}
+
+	return document.Resources, nil
+}
+
+// matchesType checks if the resource type matches any of the patterns
+func matchesType(patterns []string, kind string) bool {
+	for _, x := range patterns {
+		if matched, _ := path.Match(x, kind); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains checks if the value is in the list
+func contains(list []string, value string) bool {
+	for _, x := range list {
+		if x == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const testTemplate = `
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      AccessControl: PublicRead
+  User:
+    Type: AWS::IAM::User
+    Properties:
+      ManagedPolicyArns:
+      - arn:aws:iam::aws:policy/AdministratorAccess
+  Database:
+    Type: AWS::RDS::DBInstance
+    Properties:
+      AllocatedStorage: 500
+`
+
+func newTestPolicy(spec apiv1.CloudPolicySpec) apiv1.CloudPolicy {
+	return apiv1.CloudPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: spec}
+}
+
+func TestEvaluateNoPolicies(t *testing.T) {
+	assert.NoError(t, Evaluate(nil, testTemplate))
+}
+
+func TestEvaluateTypes(t *testing.T) {
+	cases := []struct {
+		Spec       apiv1.CloudPolicySpec
+		Violations int
+	}{
+		{Spec: apiv1.CloudPolicySpec{AllowedTypes: []string{"AWS::*"}}},
+		{Spec: apiv1.CloudPolicySpec{AllowedTypes: []string{"AWS::S3::*"}}, Violations: 2},
+		{Spec: apiv1.CloudPolicySpec{DeniedTypes: []string{"AWS::IAM::User"}}, Violations: 1},
+		{Spec: apiv1.CloudPolicySpec{DeniedTypes: []string{"AWS::EC2::*"}}},
+	}
+	for i, c := range cases {
+		err := Evaluate([]apiv1.CloudPolicy{newTestPolicy(c.Spec)}, testTemplate)
+		if c.Violations <= 0 {
+			assert.NoError(t, err, "case %d", i)
+			continue
+		}
+		if assert.Error(t, err, "case %d", i) && assert.True(t, IsViolation(err)) {
+			assert.Len(t, err.(*ViolationError).Violations, c.Violations, "case %d", i)
+		}
+	}
+}
+
+func TestEvaluateConstraints(t *testing.T) {
+	cases := []struct {
+		Constraint apiv1.PolicyConstraint
+		Violated   bool
+	}{
+		{
+			Constraint: apiv1.PolicyConstraint{
+				Operator: apiv1.OperatorNotIn, Path: "{.Properties.AccessControl}",
+				ResourceType: "AWS::S3::Bucket", Values: []string{"PublicRead", "PublicReadWrite"},
+			},
+			Violated: true,
+		},
+		{
+			Constraint: apiv1.PolicyConstraint{
+				Operator: apiv1.OperatorNotIn, Path: "{.Properties.ManagedPolicyArns[*]}",
+				ResourceType: "AWS::IAM::User", Values: []string{"arn:aws:iam::aws:policy/AdministratorAccess"},
+			},
+			Violated: true,
+		},
+		{
+			Constraint: apiv1.PolicyConstraint{
+				Operator: apiv1.OperatorLessThan, Path: ".Properties.AllocatedStorage",
+				ResourceType: "AWS::RDS::DBInstance", Values: []string{"100"},
+			},
+			Violated: true,
+		},
+		{
+			Constraint: apiv1.PolicyConstraint{
+				Operator: apiv1.OperatorEquals, Path: "{.Properties.InstanceType}",
+				ResourceType: "AWS::RDS::*", Values: []string{"db.t2.micro"},
+			},
+		},
+		{
+			Constraint: apiv1.PolicyConstraint{
+				Operator: apiv1.OperatorExists, Path: "{.Properties.DeletionProtection}",
+				ResourceType: "AWS::RDS::DBInstance",
+			},
+			Violated: true,
+		},
+	}
+	for i, c := range cases {
+		c.Constraint.Name = "test"
+		policy := newTestPolicy(apiv1.CloudPolicySpec{Constraints: []apiv1.PolicyConstraint{c.Constraint}})
+		err := Evaluate([]apiv1.CloudPolicy{policy}, testTemplate)
+		assert.Equal(t, c.Violated, IsViolation(err), "case %d, error: %v", i, err)
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const (
+	// RegoQuery is the query evaluated against the rego policies; policies must be written in
+	// the package cloud.resources and produce a set of messages from deny rules
+	RegoQuery = "data.cloud.resources.deny"
+	// RegoExtension is the file extension of policies loaded from disk or configmaps
+	RegoExtension = ".rego"
+)
+
+// RegoInput is the input document provided to the rego policies
+type RegoInput struct {
+	// Resource is the cloud resource being provisioned
+	Resource *apiv1.CloudResource `json:"resource"`
+	// Template is the cloud template the resource is built from
+	Template *apiv1.CloudTemplate `json:"template"`
+	// Namespace holds the details of the namespace of the resource
+	Namespace RegoNamespace `json:"namespace"`
+	// Rendered is the decoded rendered template
+	Rendered interface{} `json:"rendered"`
+}
+
+// RegoNamespace is the namespace of the resource as presented to the policies
+type RegoNamespace struct {
+	// Name is the name of the namespace
+	Name string `json:"name"`
+	// Labels are the labels on the namespace
+	Labels map[string]string `json:"labels"`
+}
+
+// RegoEngine holds the compiled rego policies from the various sources
+type RegoEngine struct {
+	sync.RWMutex
+	// compiler is the compiled set of modules
+	compiler *ast.Compiler
+	// sources is a map of source i.e. configmap to module name and content
+	sources map[string]map[string]string
+	// synced indicates the policies from all the sources have been loaded
+	synced bool
+}
+
+// NewRegoEngine returns an empty rego engine
+func NewRegoEngine() *RegoEngine {
+	return &RegoEngine{sources: make(map[string]map[string]string, 0)}
+}
+
+// NewRegoInput creates the policy input from the resource, template and rendered template
+func NewRegoInput(resource *apiv1.CloudResource, template *apiv1.CloudTemplate, labels map[string]string, content string) (*RegoInput, error) {
+	encoded, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the rendered template: %s", err)
+	}
+	var rendered interface{}
+	if err := json.Unmarshal(encoded, &rendered); err != nil {
+		return nil, fmt.Errorf("unable to decode the rendered template: %s", err)
+	}
+
+	return &RegoInput{
+		Namespace: RegoNamespace{Name: resource.Namespace, Labels: labels},
+		Rendered:  rendered,
+		Resource:  resource,
+		Template:  template,
+	}, nil
+}
+
+// HasPolicies checks if the engine has any policies loaded
+func (r *RegoEngine) HasPolicies() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.compiler != nil
+}
+
+// HasSynced checks if the policies from all the sources have been loaded; until then the
+// engine cannot be relied on to deny a resource
+func (r *RegoEngine) HasSynced() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.synced
+}
+
+// SetSynced marks the policies from all the sources as loaded
+func (r *RegoEngine) SetSynced() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.synced = true
+}
+
+// Sources returns a sorted list of the policy sources loaded
+func (r *RegoEngine) Sources() []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	var list []string
+	for k := range r.sources {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+
+	return list
+}
+
+// SetModules replaces the modules from a source and recompiles the policies; on a compilation
+// error the previous policies are retained
+func (r *RegoEngine) SetModules(source string, modules map[string]string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	filtered := make(map[string]string, 0)
+	for k, v := range modules {
+		if strings.HasSuffix(k, RegoExtension) {
+			filtered[k] = v
+		}
+	}
+
+	sources := make(map[string]map[string]string, len(r.sources)+1)
+	for k, v := range r.sources {
+		sources[k] = v
+	}
+	if len(filtered) > 0 {
+		sources[source] = filtered
+	} else {
+		delete(sources, source)
+	}
+
+	compiler, err := compileModules(sources)
+	if err != nil {
+		return fmt.Errorf("unable to compile policies from: %s, error: %s", source, err)
+	}
+	r.compiler = compiler
+	r.sources = sources
+
+	return nil
+}
+
+// DeleteModules removes the modules from a source
+func (r *RegoEngine) DeleteModules(source string) error {
+	return r.SetModules(source, nil)
+}
+
+// LoadDirectory loads all the rego files under a directory as a source
+func (r *RegoEngine) LoadDirectory(path string) error {
+	modules := make(map[string]string, 0)
+
+	err := filepath.Walk(path, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(filename) != RegoExtension {
+			return nil
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		modules[filename] = string(content)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read policies from directory: %s, error: %s", path, err)
+	}
+
+	return r.SetModules(path, modules)
+}
+
+// Evaluate runs the policies against the input and returns a ViolationError containing any
+// deny messages produced
+func (r *RegoEngine) Evaluate(ctx context.Context, input *RegoInput) error {
+	r.RLock()
+	compiler := r.compiler
+	r.RUnlock()
+
+	if compiler == nil {
+		return nil
+	}
+
+	// @step: convert the input into a generic document so the json tags are honoured
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	var document interface{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return err
+	}
+
+	results, err := rego.New(
+		rego.Compiler(compiler),
+		rego.Input(document),
+		rego.Query(RegoQuery),
+	).Eval(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to evaluate the rego policies: %s", err)
+	}
+
+	var messages []string
+	for _, x := range results {
+		for _, expression := range x.Expressions {
+			list, ok := expression.Value.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, message := range list {
+				messages = append(messages, fmt.Sprintf("%v", message))
+			}
+		}
+	}
+	if len(messages) <= 0 {
+		return nil
+	}
+	sort.Strings(messages)
+
+	var violations []Violation
+	for _, x := range messages {
+		violations = append(violations, Violation{
+			Message:  x,
+			Policy:   RegoQuery,
+			Resource: input.Resource.Name,
+		})
+	}
+
+	return &ViolationError{Violations: violations}
+}
+
+// compileModules parses and compiles the modules from all the sources
+func compileModules(sources map[string]map[string]string) (*ast.Compiler, error) {
+	parsed := make(map[string]*ast.Module, 0)
+	for source, modules := range sources {
+		for name, content := range modules {
+			filename := fmt.Sprintf("%s/%s", source, name)
+			if strings.HasPrefix(name, source) {
+				filename = name
+			}
+			module, err := ast.ParseModule(filename, content)
+			if err != nil {
+				return nil, err
+			}
+			parsed[filename] = module
+		}
+	}
+	if len(parsed) <= 0 {
+		return nil, nil
+	}
+
+	compiler := ast.NewCompiler()
+	if compiler.Compile(parsed); compiler.Failed() {
+		return nil, compiler.Errors
+	}
+
+	return compiler, nil
+}
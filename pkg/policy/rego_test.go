@@ -0,0 +1,75 @@
+/*
+Copyright 2018 All rights reserved - Appvia.io
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+)
+
+const testRegoPolicy = `
+package cloud.resources
+
+deny[msg] {
+	input.namespace.labels["environment"] == "production"
+	input.rendered.Resources[name].Type == "AWS::IAM::User"
+	msg := sprintf("resource: %s, users are not permitted in production", [name])
+}
+`
+
+func newTestRegoInput(t *testing.T, labels map[string]string) *RegoInput {
+	resource := &apiv1.CloudResource{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	input, err := NewRegoInput(resource, &apiv1.CloudTemplate{}, labels, testTemplate)
+	require.NoError(t, err)
+
+	return input
+}
+
+func TestRegoEngineNoPolicies(t *testing.T) {
+	engine := NewRegoEngine()
+	assert.False(t, engine.HasPolicies())
+	assert.NoError(t, engine.Evaluate(context.TODO(), newTestRegoInput(t, nil)))
+}
+
+func TestRegoEngineEvaluate(t *testing.T) {
+	engine := NewRegoEngine()
+	require.NoError(t, engine.SetModules("test", map[string]string{"users.rego": testRegoPolicy}))
+	assert.True(t, engine.HasPolicies())
+
+	assert.NoError(t, engine.Evaluate(context.TODO(), newTestRegoInput(t, map[string]string{"environment": "dev"})))
+
+	err := engine.Evaluate(context.TODO(), newTestRegoInput(t, map[string]string{"environment": "production"}))
+	if assert.True(t, IsViolation(err)) {
+		assert.Len(t, err.(*ViolationError).Violations, 1)
+	}
+
+	require.NoError(t, engine.DeleteModules("test"))
+	assert.False(t, engine.HasPolicies())
+}
+
+func TestRegoEngineInvalidPolicy(t *testing.T) {
+	engine := NewRegoEngine()
+	require.NoError(t, engine.SetModules("test", map[string]string{"users.rego": testRegoPolicy}))
+	assert.Error(t, engine.SetModules("broken", map[string]string{"broken.rego": "package cloud.resources\ndeny[msg] {"}))
+	assert.Equal(t, []string{"test"}, engine.Sources())
+}
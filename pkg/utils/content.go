@@ -0,0 +1,133 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+// ContentLoader retrieves the content of templates sourced from configmaps or the templates directory
+type ContentLoader struct {
+	// client is the kubernetes client
+	client kubernetes.Interface
+	// dir is the templates directory
+	dir string
+}
+
+// NewContentLoader creates a content loader, the directory is optional
+func NewContentLoader(client kubernetes.Interface, dir string) *ContentLoader {
+	return &ContentLoader{client: client, dir: dir}
+}
+
+// Load returns a copy of the template with the content inlined from the source, a template without
+// a content source is returned as is
+func (c *ContentLoader) Load(template *apiv1.CloudTemplate) (*apiv1.CloudTemplate, error) {
+	source := template.Spec.ContentFrom
+	if source == nil {
+		return template, nil
+	}
+	if c == nil {
+		return nil, errors.New("no content loader configured")
+	}
+
+	var content string
+	var err error
+
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		content, err = c.fromConfigMap(source.ConfigMapKeyRef)
+	case source.File != "":
+		content, err = c.fromFile(source.File)
+	default:
+		err = errors.New("no content source defined")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := template.DeepCopy()
+	loaded.Spec.Content = content
+	loaded.Spec.ContentFrom = nil
+
+	return loaded, nil
+}
+
+// Path returns the path of the file within the templates directory
+func (c *ContentLoader) Path(name string) (string, error) {
+	if c.dir == "" {
+		return "", errors.New("no templates directory configured")
+	}
+	path := filepath.Join(c.dir, filepath.Clean(name))
+	if !strings.HasPrefix(path, filepath.Clean(c.dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("file: %s is outside the templates directory", name)
+	}
+
+	return path, nil
+}
+
+// fromConfigMap retrieves the content from the configmap key
+func (c *ContentLoader) fromConfigMap(ref *apiv1.ConfigMapKeyReference) (string, error) {
+	if c.client == nil {
+		return "", fmt.Errorf("configmap: %s/%s cannot be read without a kubernetes client", ref.Namespace, ref.Name)
+	}
+	cm, err := c.client.CoreV1().ConfigMaps(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve configmap: %s/%s, error: %s", ref.Namespace, ref.Name, err)
+	}
+	if cm.GetLabels()[models.TemplateContentLabel] != "true" {
+		return "", fmt.Errorf("configmap: %s/%s does not have the label: %s=true", ref.Namespace, ref.Name, models.TemplateContentLabel)
+	}
+	content, found := cm.Data[ref.Key]
+	if !found {
+		return "", fmt.Errorf("configmap: %s/%s does not have the key: %s", ref.Namespace, ref.Name, ref.Key)
+	}
+
+	return content, nil
+}
+
+// fromFile reads the content from the file in the templates directory
+func (c *ContentLoader) fromFile(name string) (string, error) {
+	path, err := c.Path(name)
+	if err != nil {
+		return "", err
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the template file: %s, error: %s", name, err)
+	}
+
+	return string(content), nil
+}
+
+// ContentChecksum returns the sha256 of the template content
+func ContentChecksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
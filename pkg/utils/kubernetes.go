@@ -26,19 +26,31 @@ import (
 
 	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
 	"github.com/gambol99/resources/pkg/client/clientset/versioned"
+	"github.com/gambol99/resources/pkg/models"
 )
 
 // UpdateCloudStatus is responsible for updating a cloud status
 func UpdateCloudStatus(client versioned.Interface, status *apiv1.CloudStatus) error {
 	return Retry(3, time.Second*2, func() error {
 		// @check if the status already exists
-		if _, err := client.CloudV1().CloudStatuses(status.Namespace).Get(status.Name, metav1.GetOptions{}); err != nil {
+		current, err := client.CloudV1().CloudStatuses(status.Namespace).Get(status.Name, metav1.GetOptions{})
+		if err != nil {
 			if kerrors.IsNotFound(err) {
 				_, err = client.CloudV1().CloudStatuses(status.Namespace).Create(status)
 			}
 			return err
 		}
-		_, err := client.CloudV1().CloudStatuses(status.Namespace).Update(status)
+		// @step: merge the conditions so unchanged conditions retain their transition time
+		conditions := status.Conditions
+		status.Conditions = current.Conditions
+		for _, x := range conditions {
+			status.SetCondition(x)
+		}
+		// @step: the checkpoint is retained unless replaced, it is only written as the reconcile progresses
+		if status.Checkpoint == nil {
+			status.Checkpoint = current.Checkpoint
+		}
+		_, err = client.CloudV1().CloudStatuses(status.Namespace).Update(status)
 
 		return err
 	})
@@ -83,6 +95,18 @@ func UpdateKubernetesSecret(client kubernetes.Interface, name, namespace string,
 	})
 }
 
+// DeleteKubernetesSecret is responsible for deleting a kube secret, a missing secret is ignored
+func DeleteKubernetesSecret(client kubernetes.Interface, name, namespace string) error {
+	return Retry(3, time.Second*2, func() error {
+		err := client.CoreV1().Secrets(namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+
+		return nil
+	})
+}
+
 // FindKubernetesSecret is resposible for retrieving secrets from kubernetes
 func FindKubernetesSecret(client kubernetes.Interface, name, namespace string) (map[string]string, error) {
 	var err error
@@ -95,8 +119,15 @@ func FindKubernetesSecret(client kubernetes.Interface, name, namespace string) (
 	if err != nil {
 		return map[string]string{}, err
 	}
+	values := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		values[k] = string(v)
+	}
+	for k, v := range secret.StringData {
+		values[k] = v
+	}
 
-	return secret.StringData, nil
+	return values, nil
 }
 
 // FindCloudTemplate is responsible for retrieving the cloud template
@@ -104,6 +135,73 @@ func FindCloudTemplate(client versioned.Interface, name string) (*apiv1.CloudTem
 	return client.Cloud().CloudTemplates().Get(name, metav1.GetOptions{})
 }
 
+// FindCloudTemplateFragment is responsible for retrieving a template fragment
+func FindCloudTemplateFragment(client versioned.Interface, name string) (*apiv1.CloudTemplateFragment, error) {
+	return client.Cloud().CloudTemplateFragments().Get(name, metav1.GetOptions{})
+}
+
+// ResolveCloudTemplate is responsible for resolving the content, bases and fragments of the cloud template
+func ResolveCloudTemplate(client versioned.Interface, loader *ContentLoader, template *apiv1.CloudTemplate) (*models.ResolvedTemplate, error) {
+	template, err := loader.Load(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.ResolveTemplate(template,
+		func(name string) (*apiv1.CloudTemplate, error) {
+			base, err := FindCloudTemplate(client, name)
+			if err != nil {
+				return nil, err
+			}
+
+			return loader.Load(base)
+		},
+		func(name string) (*apiv1.CloudTemplateFragment, error) {
+			return FindCloudTemplateFragment(client, name)
+		},
+	)
+}
+
+// FindCloudQuotas is responsible for retrieving the cloud quotas in a namespace
+func FindCloudQuotas(client versioned.Interface, namespace string) ([]apiv1.CloudQuota, error) {
+	list, err := client.Cloud().CloudQuotas(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// UpdateCloudQuotaStatus is responsible for updating the usage on a cloud quota
+func UpdateCloudQuotaStatus(client versioned.Interface, name, namespace string, status apiv1.CloudQuotaStatus) error {
+	return Retry(3, time.Second*2, func() error {
+		quota, err := client.CloudV1().CloudQuotas(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		quota.Status = status
+		_, err = client.CloudV1().CloudQuotas(namespace).Update(quota)
+
+		return err
+	})
+}
+
+// FindCloudResourcesByTemplate is responsible for retrieving the cloud resources in all namespaces using the template
+func FindCloudResourcesByTemplate(client versioned.Interface, name string) ([]apiv1.CloudResource, error) {
+	list, err := client.Cloud().CloudResources(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var resources []apiv1.CloudResource
+	for _, x := range list.Items {
+		if x.Spec.TemplateName == name {
+			resources = append(resources, x)
+		}
+	}
+
+	return resources, nil
+}
+
 // FindCloudResource is responsible for retrieving a cloud resource
 func FindCloudResource(client versioned.Interface, name, namespace string) (*apiv1.CloudResource, error) {
 	return client.Cloud().CloudResources(namespace).Get(name, metav1.GetOptions{})
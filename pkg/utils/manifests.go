@@ -0,0 +1,152 @@
+/*
+Copyright 2018 All rights reserved - Appvia
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/gambol99/resources/pkg/apis/resources/v1"
+	"github.com/gambol99/resources/pkg/models"
+)
+
+const (
+	// KindTemplateTests is the kind of a sidecar document adding test cases to a template
+	KindTemplateTests = "CloudTemplateTests"
+)
+
+// documentSeparator splits a yaml stream into documents
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// TemplateTests is a sidecar document holding the test cases of a template, the name is the template
+type TemplateTests struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Tests are the test cases added to the template
+	Tests []apiv1.TemplateTest `json:"tests"`
+}
+
+// Manifests are the cloud objects read from yaml files, used when working without a cluster
+type Manifests struct {
+	// Fragments are the template fragments
+	Fragments []*apiv1.CloudTemplateFragment
+	// Resources are the cloud resources
+	Resources []*apiv1.CloudResource
+	// Templates are the cloud templates
+	Templates []*apiv1.CloudTemplate
+}
+
+// ReadManifests reads the cloud objects from the yaml files; documents of other kinds are ignored and
+// the test cases of the sidecar documents are added to their templates
+func ReadManifests(paths ...string) (*Manifests, error) {
+	manifests := &Manifests{}
+
+	var sidecars []*TemplateTests
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for i, document := range documentSeparator.Split(string(content), -1) {
+			if strings.TrimSpace(document) == "" {
+				continue
+			}
+			meta := &metav1.TypeMeta{}
+			if err := yaml.Unmarshal([]byte(document), meta); err != nil {
+				return nil, fmt.Errorf("file: %s, document: %d, error: %s", path, i, err)
+			}
+
+			var object interface{}
+			switch meta.Kind {
+			case "CloudResource":
+				x := &apiv1.CloudResource{}
+				manifests.Resources, object = append(manifests.Resources, x), x
+			case "CloudTemplate":
+				x := &apiv1.CloudTemplate{}
+				manifests.Templates, object = append(manifests.Templates, x), x
+			case "CloudTemplateFragment":
+				x := &apiv1.CloudTemplateFragment{}
+				manifests.Fragments, object = append(manifests.Fragments, x), x
+			case KindTemplateTests:
+				x := &TemplateTests{}
+				sidecars, object = append(sidecars, x), x
+			default:
+				continue
+			}
+			if err := yaml.Unmarshal([]byte(document), object); err != nil {
+				return nil, fmt.Errorf("file: %s, document: %d, error: %s", path, i, err)
+			}
+		}
+	}
+
+	for _, x := range sidecars {
+		template, err := manifests.Template(x.Name)
+		if err != nil {
+			return nil, fmt.Errorf("test cases for unknown template: %s", x.Name)
+		}
+		template.Spec.Tests = append(template.Spec.Tests, x.Tests...)
+	}
+
+	return manifests, nil
+}
+
+// Template returns the template from the manifests
+func (m *Manifests) Template(name string) (*apiv1.CloudTemplate, error) {
+	for _, x := range m.Templates {
+		if x.Name == name {
+			return x, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cloud template: %s does not exist", name)
+}
+
+// Fragment returns the template fragment from the manifests
+func (m *Manifests) Fragment(name string) (*apiv1.CloudTemplateFragment, error) {
+	for _, x := range m.Fragments {
+		if x.Name == name {
+			return x, nil
+		}
+	}
+
+	return nil, fmt.Errorf("template fragment: %s does not exist", name)
+}
+
+// Resolve resolves the content, bases and fragments of the template from the manifests
+func (m *Manifests) Resolve(loader *ContentLoader, template *apiv1.CloudTemplate) (*models.ResolvedTemplate, error) {
+	template, err := loader.Load(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.ResolveTemplate(template,
+		func(name string) (*apiv1.CloudTemplate, error) {
+			base, err := m.Template(name)
+			if err != nil {
+				return nil, err
+			}
+
+			return loader.Load(base)
+		},
+		m.Fragment,
+	)
+}